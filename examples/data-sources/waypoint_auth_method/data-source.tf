@@ -0,0 +1,11 @@
+data "waypoint_auth_method" "okta" {
+  name = "okta"
+}
+
+output "okta_login_command" {
+  value = "waypoint login -auth-method=${data.waypoint_auth_method.okta.name}"
+}
+
+output "okta_redirect_urls" {
+  value = data.waypoint_auth_method.okta.oidc[0].allowed_redirect_urls
+}
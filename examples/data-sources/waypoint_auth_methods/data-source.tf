@@ -0,0 +1,8 @@
+data "waypoint_auth_methods" "all" {}
+
+output "login_options" {
+  value = {
+    for am in data.waypoint_auth_methods.all.auth_methods :
+    am.name => am.display_name
+  }
+}
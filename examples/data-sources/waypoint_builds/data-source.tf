@@ -0,0 +1,9 @@
+data "waypoint_builds" "example" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  workspace_name   = "default"
+}
+
+output "latest_build_artifact" {
+  value = data.waypoint_builds.example.builds[0].artifact_json
+}
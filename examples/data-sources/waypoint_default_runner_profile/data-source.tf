@@ -0,0 +1,5 @@
+data "waypoint_default_runner_profile" "default" {}
+
+output "default_runner_profile" {
+  value = data.waypoint_default_runner_profile.default.profile_name
+}
@@ -0,0 +1,9 @@
+data "waypoint_deployments" "example" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  sequence         = 3
+}
+
+output "deployment_url" {
+  value = data.waypoint_deployments.example.deployments[0].url
+}
@@ -0,0 +1,4 @@
+data "waypoint_hostnames" "example" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+}
@@ -0,0 +1,9 @@
+data "waypoint_releases" "example" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  latest           = true
+}
+
+output "release_url" {
+  value = data.waypoint_releases.example.releases[0].url
+}
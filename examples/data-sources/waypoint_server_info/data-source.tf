@@ -0,0 +1,5 @@
+data "waypoint_server_info" "example" {}
+
+output "waypoint_server_version" {
+  value = data.waypoint_server_info.example.version
+}
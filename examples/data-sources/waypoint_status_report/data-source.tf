@@ -0,0 +1,18 @@
+data "waypoint_releases" "example" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  latest           = true
+}
+
+data "waypoint_status_report" "example" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  release_id       = data.waypoint_releases.example.releases[0].id
+
+  lifecycle {
+    postcondition {
+      condition     = self.health_status == "READY"
+      error_message = "The latest release of example-nodejs is not ready: ${self.health_message}"
+    }
+  }
+}
@@ -0,0 +1,7 @@
+locals {
+  apps = provider::waypoint::parse_waypoint_hcl(file("${path.module}/waypoint.hcl"))
+}
+
+output "platforms" {
+  value = { for app in local.apps : app.name => app.platform }
+}
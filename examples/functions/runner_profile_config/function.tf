@@ -0,0 +1,15 @@
+resource "waypoint_runner_profile" "kubernetes" {
+  profile_name = "kubernetes"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "kubernetes"
+
+  plugin_config = provider::waypoint::runner_profile_config({
+    namespace       = "waypoint-runners"
+    service_account = "waypoint-runner"
+
+    cpu = {
+      request = "250m"
+      limit   = "500m"
+    }
+  })
+}
@@ -4,14 +4,19 @@ resource "waypoint_project" "example" {
   remote_runners_enabled = true
 
   data_source_git {
-    git_url                   = "https://github.com/hashicorp/waypoint-examples"
-    git_path                  = "docker/go"
-    git_ref                   = "HEAD"
-    file_change_signal        = "some-signal"
-    git_poll_interval_seconds = 15
+    git_url            = "https://github.com/hashicorp/waypoint-examples"
+    git_path           = "docker/go"
+    git_ref            = "HEAD"
+    file_change_signal = "some-signal"
   }
 
-  app_status_poll_seconds = 12
+  data_source_poll {
+    interval = "15s"
+  }
+
+  status_report_poll {
+    interval = "12s"
+  }
 
   project_variables = {
     name       = "devopsrob"
@@ -1,9 +1,12 @@
 resource "waypoint_runner_profile" "example" {
-  profile_name     = "example"
-  oci_url          = "hashicorp/waypoint-odr:latest"
-  plugin_type      = "docker"
-  default          = true
-  target_runner_id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  profile_name = "example"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
 
   environment_variables = {
     VAULT_ADDR           = "https://localhost:8200"
@@ -23,4 +23,17 @@ resource "waypoint_auth_method_oidc" "okta" {
   discovery_ca_pem = [
     "cert1.crt"
   ]
-}
\ No newline at end of file
+}
+
+# Keep the client secret out of the Terraform state, bumping
+# client_secret_version whenever the secret is rotated.
+resource "waypoint_auth_method_oidc" "azure" {
+  name                  = "azure-ad"
+  client_id             = "..."
+  client_secret_wo      = var.azure_client_secret
+  client_secret_version = 1
+  discovery_url         = "https://login.microsoftonline.com/<tenant-id>/v2.0"
+  allowed_redirect_urls = [
+    "https://localhost:9702/auth/oidc-callback",
+  ]
+}
@@ -0,0 +1,6 @@
+resource "waypoint_hostname" "example" {
+  hostname         = "fabulous-panda"
+  project_name     = "example"
+  application_name = "example-nodejs"
+  workspace_name   = "default"
+}
@@ -0,0 +1,23 @@
+resource "waypoint_job" "example" {
+  project_name     = waypoint_project.example.project_name
+  application_name = "example-nodejs"
+  workspace_name   = "default"
+  operation        = "up"
+
+  variables = {
+    image_tag = "v1.2.3"
+  }
+
+  # Run the operation again whenever the git ref changes
+  triggers = {
+    git_ref = waypoint_project.example.data_source_git[0].git_ref
+  }
+
+  timeouts {
+    create = "20m"
+  }
+}
+
+output "release_url" {
+  value = waypoint_job.example.release_url
+}
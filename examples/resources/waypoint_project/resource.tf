@@ -4,14 +4,19 @@ resource "waypoint_project" "example" {
   remote_runners_enabled = false
 
   data_source_git {
-    git_url                   = "https://github.com/hashicorp/waypoint-examples"
-    git_path                  = "docker/go"
-    git_ref                   = "HEAD"
-    file_change_signal        = "some-signal"
-    git_poll_interval_seconds = 15
+    git_url            = "https://github.com/hashicorp/waypoint-examples"
+    git_path           = "docker/go"
+    git_ref            = "HEAD"
+    file_change_signal = "some-signal"
   }
 
-  app_status_poll_seconds = 12
+  data_source_poll {
+    interval = "15s"
+  }
+
+  status_report_poll {
+    interval = "12s"
+  }
 
   project_variables = {
     name       = "devopsrob"
@@ -27,14 +32,19 @@ resource "waypoint_project" "example" {
   remote_runners_enabled = true
 
   data_source_git {
-    git_url                   = "https://github.com/hashicorp/waypoint-examples"
-    git_path                  = "docker/go"
-    git_ref                   = "HEAD"
-    file_change_signal        = "some-signal"
-    git_poll_interval_seconds = 15
+    git_url            = "https://github.com/hashicorp/waypoint-examples"
+    git_path           = "docker/go"
+    git_ref            = "HEAD"
+    file_change_signal = "some-signal"
   }
 
-  app_status_poll_seconds = 12
+  data_source_poll {
+    interval = "15s"
+  }
+
+  status_report_poll {
+    interval = "12s"
+  }
 
   project_variables = {
     name       = "devopsrob"
@@ -64,4 +74,43 @@ HAqVePERhISfN6cwZt5p8B3/JUwSR8el66DF7Jm57BM=
 -----END RSA PRIVATE KEY-----
 EOF
   }
-}
\ No newline at end of file
+}
+##Inline waypoint.hcl example
+resource "waypoint_project" "inline" {
+  project_name = "inline"
+
+  data_source_git {
+    git_url = "https://github.com/hashicorp/waypoint-examples"
+    git_ref = "HEAD"
+  }
+
+  waypoint_hcl = <<EOF
+project = "inline"
+
+app "web" {
+  build {
+    use "docker" {}
+  }
+
+  deploy {
+    use "docker" {}
+  }
+}
+EOF
+}
+##Git auth ssh example keeping the private key out of the state
+resource "waypoint_project" "write_only" {
+  project_name = "write-only"
+
+  data_source_git {
+    git_url = "ssh://github.com/hashicorp/waypoint-examples"
+    git_ref = "HEAD"
+  }
+
+  git_auth_ssh {
+    git_user             = "devops-rob"
+    ssh_private_key_file = "${path.module}/id_rsa"
+    passphrase_wo        = var.ssh_passphrase
+    credentials_version  = 1
+  }
+}
@@ -0,0 +1,20 @@
+resource "waypoint_project_variable" "port" {
+  project_name = "example"
+  name         = "port"
+  type         = "number"
+  value        = "8080"
+}
+
+resource "waypoint_project_variable" "registry_token" {
+  project_name = "example"
+  name         = "registry_token"
+  value        = var.registry_token
+  sensitive    = true
+}
+
+resource "waypoint_project_variable" "regions" {
+  project_name = "example"
+  name         = "regions"
+  type         = "hcl"
+  value        = jsonencode(["eu-west-1", "us-east-1"])
+}
@@ -4,8 +4,11 @@ resource "waypoint_runner_profile" "target_id" {
   oci_url      = "hashicorp/waypoint-odr:latest"
   plugin_type  = "docker"
   default      = true
-  target_runner_labels = {
-    app = "payments"
+
+  target_runner {
+    labels = {
+      app = "payments"
+    }
   }
 
   environment_variables = {
@@ -16,14 +19,37 @@ resource "waypoint_runner_profile" "target_id" {
 
 ## Example with runner id
 resource "waypoint_runner_profile" "example" {
-  profile_name     = "example"
-  oci_url          = "hashicorp/waypoint-odr:latest"
-  plugin_type      = "docker"
-  default          = true
-  target_runner_id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  profile_name = "example"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
 
   environment_variables = {
     VAULT_ADDR           = "https://localhost:8200"
     VAULT_CLIENT_TIMEOUT = "30s"
   }
 }
+
+## Example with typed plugin configuration
+resource "waypoint_runner_profile" "kubernetes" {
+  profile_name = "kubernetes"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "kubernetes"
+
+  target_runner {
+    any = true
+  }
+
+  kubernetes_config {
+    namespace = "waypoint"
+
+    cpu {
+      request = "250m"
+      limit   = "500m"
+    }
+  }
+}
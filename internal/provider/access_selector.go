@@ -0,0 +1,335 @@
+package waypoint
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/go-bexpr/grammar"
+)
+
+// accessSelectorError is a problem found in an access selector, at the
+// byte offset of the token it is about.
+type accessSelectorError struct {
+	Offset  int
+	Token   string
+	Message string
+}
+
+// Detail returns the message followed by the selector with the token
+// underlined, so that the bad token can be found in long expressions.
+func (e accessSelectorError) Detail(selector string) string {
+	width := len(e.Token)
+	if width == 0 {
+		width = 1
+	}
+
+	return fmt.Sprintf("%s\n\n  %s\n  %s%s", e.Message, selector, strings.Repeat(" ", e.Offset), strings.Repeat("^", width))
+}
+
+// bexprErrorOffset matches the position that go-bexpr prefixes its parse
+// errors with, as line:column (offset).
+var bexprErrorOffset = regexp.MustCompile(`^\d+:\d+ \((\d+)\): (?:rule \w+: )?`)
+
+// validateAccessSelector parses the go-bexpr access selector of an auth
+// method and checks the claims it refers to. The selector is evaluated by
+// Waypoint against value, the claims named by the values of claimMappings,
+// and list, those named by the values of listClaimMappings. A nil mapping is
+// unknown and not checked.
+func validateAccessSelector(selector string, claimMappings, listClaimMappings map[string]string) []accessSelectorError {
+	ast, err := grammar.Parse("", []byte(selector))
+	if err != nil {
+		var errs []accessSelectorError
+
+		for _, line := range strings.Split(err.Error(), "\n") {
+			e := accessSelectorError{Offset: len(selector), Message: line}
+
+			if m := bexprErrorOffset.FindStringSubmatch(line); m != nil {
+				e.Offset, _ = strconv.Atoi(m[1])
+				e.Message = strings.TrimPrefix(line, m[0])
+			}
+
+			if e.Offset > len(selector) {
+				e.Offset = len(selector)
+			}
+
+			e.Token = firstToken(selector[e.Offset:])
+			e.Message = "Invalid access selector: " + e.Message
+			errs = append(errs, e)
+		}
+
+		return errs
+	}
+
+	v := &accessSelectorValidator{
+		selector: selector,
+		mappings: map[string]map[string]string{
+			"value": claimMappings,
+			"list":  listClaimMappings,
+		},
+		bindings: map[string]bool{},
+		tokens:   scanSelectors(selector),
+	}
+	v.expression(ast.(grammar.Expression))
+
+	return v.errs
+}
+
+type accessSelectorValidator struct {
+	selector string
+	mappings map[string]map[string]string
+	bindings map[string]bool
+	errs     []accessSelectorError
+
+	// tokens are the selectors of the source, and next the index of the
+	// first one that was not yet matched with a selector of the AST, which
+	// go-bexpr returns without positions.
+	tokens []selectorToken
+	next   int
+}
+
+func (v *accessSelectorValidator) expression(expr grammar.Expression) {
+	switch e := expr.(type) {
+	case *grammar.UnaryExpression:
+		v.expression(e.Operand)
+	case *grammar.BinaryExpression:
+		v.expression(e.Left)
+		v.expression(e.Right)
+	case *grammar.MatchExpression:
+		v.selectorPath(e.Selector, e.Operator)
+	case *grammar.CollectionExpression:
+		v.selectorPath(e.Selector, grammar.MatchIn)
+
+		// The names bound by the collection expression are valid selectors
+		// of its inner expression only.
+		outer := v.bindings
+		v.bindings = map[string]bool{}
+		for name := range outer {
+			v.bindings[name] = true
+		}
+
+		for _, name := range []string{e.NameBinding.Default, e.NameBinding.Index, e.NameBinding.Value} {
+			if name != "" {
+				v.bindings[name] = true
+			}
+		}
+
+		v.expression(e.Inner)
+		v.bindings = outer
+	}
+}
+
+func (v *accessSelectorValidator) selectorPath(sel grammar.Selector, op grammar.MatchOperator) {
+	at := v.locate(sel)
+
+	if len(sel.Path) == 0 || v.bindings[sel.Path[0]] {
+		return
+	}
+
+	token := sel.String()
+
+	mappings, ok := v.mappings[sel.Path[0]]
+	if !ok {
+		v.errorAt(at, fmt.Sprintf("Unknown selector %q. Selectors start with value. for claim_mappings or list. for list_claim_mappings.", token))
+		return
+	}
+
+	attribute := "claim_mappings"
+	if sel.Path[0] == "list" {
+		attribute = "list_claim_mappings"
+	}
+
+	if len(sel.Path) != 2 {
+		v.errorAt(at, fmt.Sprintf("Invalid selector %q. It must name a single claim, such as %s.<name> where <name> is a value of %s.", token, sel.Path[0], attribute))
+		return
+	}
+
+	if mappings != nil && !mappingNames(mappings)[sel.Path[1]] {
+		names := sortedKeys(mappingNames(mappings))
+
+		msg := fmt.Sprintf("Unknown claim %q in %q: no key of %s maps to it.", sel.Path[1], token, attribute)
+		if len(names) > 0 {
+			msg += fmt.Sprintf(" Available names are: %s.", strings.Join(names, ", "))
+		}
+
+		v.errorAt(at, msg)
+		return
+	}
+
+	if sel.Path[0] == "list" {
+		switch op {
+		case grammar.MatchIn, grammar.MatchNotIn, grammar.MatchIsEmpty, grammar.MatchIsNotEmpty:
+		default:
+			v.errorAt(at, fmt.Sprintf("%q is a list, which can only be used with in, not in, is empty and is not empty, not %s.", token, strings.ToLower(op.String())))
+		}
+	}
+}
+
+// locate returns the token of sel in the source. Selectors are visited in
+// the order they are written, so it is the next token with the same path.
+func (v *accessSelectorValidator) locate(sel grammar.Selector) selectorToken {
+	for i := v.next; i < len(v.tokens); i++ {
+		if slices.Equal(v.tokens[i].path, sel.Path) {
+			v.next = i + 1
+			return v.tokens[i]
+		}
+	}
+
+	return selectorToken{text: sel.String()}
+}
+
+func (v *accessSelectorValidator) errorAt(at selectorToken, message string) {
+	v.errs = append(v.errs, accessSelectorError{Offset: at.offset, Token: at.text, Message: message})
+}
+
+// selectorToken is a selector as written in an access selector.
+type selectorToken struct {
+	offset int
+	text   string
+	path   []string
+}
+
+// scanSelectors returns the words of a parsed access selector that may be
+// selectors, such as value.email, value["email"] or "/value/email", in
+// order. String literals and the names bound by collection expressions are
+// skipped. Keywords are returned too, but do not match the path of a
+// selector.
+func scanSelectors(src string) []selectorToken {
+	var tokens []selectorToken
+
+	for i := 0; i < len(src); {
+		c := src[i]
+
+		switch {
+		case c == '`':
+			i += stringLiteralLength(src[i:])
+		case c == '"':
+			n := stringLiteralLength(src[i:])
+			if literal := src[i : i+n]; strings.HasPrefix(literal, `"/`) && strings.HasSuffix(literal, `"`) {
+				tokens = append(tokens, selectorToken{offset: i, text: literal, path: jsonPointerPath(literal[2 : n-1])})
+			}
+			i += n
+		case isLetter(c) && (i == 0 || !isIdentifierChar(src[i-1]) && src[i-1] != '.'):
+			token := scanSelector(src, i)
+			tokens = append(tokens, token)
+			i += len(token.text)
+
+			// Skip the names bound by all and any, up to their block.
+			if token.text == "as" {
+				if j := strings.IndexByte(src[i:], '{'); j >= 0 {
+					i += j
+				}
+			}
+		default:
+			i++
+		}
+	}
+
+	return tokens
+}
+
+// scanSelector returns the selector written at src[start:], which starts
+// with a letter.
+func scanSelector(src string, start int) selectorToken {
+	identifier := func(i int) int {
+		for i < len(src) && isIdentifierChar(src[i]) {
+			i++
+		}
+		return i
+	}
+
+	i := identifier(start)
+	path := []string{src[start:i]}
+
+	for i < len(src) {
+		switch {
+		case src[i] == '.' && i+1 < len(src) && isIdentifierChar(src[i+1]):
+			end := identifier(i + 1)
+			path = append(path, src[i+1:end])
+			i = end
+		case src[i] == '[':
+			j := i + 1 + len(src[i+1:]) - len(strings.TrimLeft(src[i+1:], " \t\r\n"))
+			n := stringLiteralLength(src[j:])
+			literal, err := strconv.Unquote(src[j : j+n])
+			if n == 0 || err != nil {
+				return selectorToken{offset: start, text: src[start:i], path: path}
+			}
+
+			end := j + n + len(src[j+n:]) - len(strings.TrimLeft(src[j+n:], " \t\r\n"))
+			if end >= len(src) || src[end] != ']' {
+				return selectorToken{offset: start, text: src[start:i], path: path}
+			}
+
+			path = append(path, literal)
+			i = end + 1
+		default:
+			return selectorToken{offset: start, text: src[start:i], path: path}
+		}
+	}
+
+	return selectorToken{offset: start, text: src[start:i], path: path}
+}
+
+// stringLiteralLength returns the length of the string literal at the start
+// of s, quotes included, or 0 when s does not start with one. go-bexpr
+// string literals have no escaped quotes.
+func stringLiteralLength(s string) int {
+	if s == "" || s[0] != '"' && s[0] != '`' {
+		return 0
+	}
+
+	if i := strings.IndexByte(s[1:], s[0]); i >= 0 {
+		return i + 2
+	}
+
+	return len(s)
+}
+
+// jsonPointerPath returns the path of a JSON pointer without its leading
+// slash, as go-bexpr stores it.
+func jsonPointerPath(pointer string) []string {
+	path := strings.Split(pointer, "/")
+	for i, part := range path {
+		path[i] = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+	}
+
+	return path
+}
+
+func isLetter(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z'
+}
+
+// isIdentifierChar reports whether c may follow the first letter of a
+// go-bexpr identifier.
+func isIdentifierChar(c byte) bool {
+	return isLetter(c) || c >= '0' && c <= '9' || c == '_' || c == '/'
+}
+
+// mappingNames returns the names claims are mapped to, the values of a
+// claim mapping.
+func mappingNames(mappings map[string]string) map[string]bool {
+	names := map[string]bool{}
+	for _, name := range mappings {
+		names[name] = true
+	}
+
+	return names
+}
+
+// firstToken returns the word at the start of s, or its first character.
+func firstToken(s string) string {
+	if s == "" {
+		return ""
+	}
+
+	if i := strings.IndexAny(s, " \t\n()\""); i > 0 {
+		return s[:i]
+	} else if i == 0 {
+		return s[:1]
+	}
+
+	return s
+}
@@ -0,0 +1,98 @@
+package waypoint
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestValidateAccessSelector(t *testing.T) {
+	claimMappings := map[string]string{"email": "email", "http://example.com/team": "team"}
+	listClaimMappings := map[string]string{"groups": "groups"}
+
+	cases := []struct {
+		selector          string
+		claimMappings     map[string]string
+		listClaimMappings map[string]string
+		wantError         bool
+		wantToken         string
+	}{
+		{"mycompany in list.groups", claimMappings, listClaimMappings, false, ""},
+		{`"admin" in list.groups and value.team == "platform"`, claimMappings, listClaimMappings, false, ""},
+		{`value.email matches ".*@example.com$"`, claimMappings, listClaimMappings, false, ""},
+		{`list.groups is not empty`, claimMappings, listClaimMappings, false, ""},
+		{`all list.groups as g { g != "contractors" }`, claimMappings, listClaimMappings, false, ""},
+		{`"admin" in list.grops`, claimMappings, listClaimMappings, true, "list.grops"},
+		{`value.emial == "rob@example.com"`, claimMappings, listClaimMappings, true, "value.emial"},
+		{`value.groups == "admin"`, claimMappings, listClaimMappings, true, "value.groups"},
+		{`list.groups == "admin"`, claimMappings, listClaimMappings, true, "list.groups"},
+		{`groups contains "admin"`, claimMappings, listClaimMappings, true, "groups"},
+		{`value.email.domain == "example.com"`, claimMappings, listClaimMappings, true, "value.email.domain"},
+		{`value.email === "rob@example.com"`, claimMappings, listClaimMappings, true, "="},
+		{`value.email == "rob@example.com" and`, claimMappings, listClaimMappings, true, ""},
+		// Unknown mappings are not checked.
+		{`"admin" in list.anything`, claimMappings, nil, false, ""},
+		{`"admin" in list.groups`, claimMappings, map[string]string{}, true, "list.groups"},
+		// Bound names are only valid in the block of their collection expression.
+		{`(all list.groups as g { g != "contractors" }) and (any list.groups as h { h == "admin" })`, claimMappings, listClaimMappings, false, ""},
+		{`(all list.groups as g { g != "contractors" }) and g == "admin"`, claimMappings, listClaimMappings, true, "g"},
+	}
+
+	for _, tc := range cases {
+		errs := validateAccessSelector(tc.selector, tc.claimMappings, tc.listClaimMappings)
+
+		if (len(errs) > 0) != tc.wantError {
+			t.Errorf("%s: got errors %v, want error %t", tc.selector, errs, tc.wantError)
+			continue
+		}
+
+		for _, e := range errs {
+			if e.Offset < 0 || e.Offset > len(tc.selector) || !strings.HasPrefix(tc.selector[e.Offset:], e.Token) {
+				t.Errorf("%s: error %q does not point at its token %q", tc.selector, e.Message, e.Token)
+			}
+
+			if tc.wantToken != "" && e.Token != tc.wantToken {
+				t.Errorf("%s: got token %q, want %q", tc.selector, e.Token, tc.wantToken)
+			}
+		}
+	}
+}
+
+func TestValidateAccessSelectorOffset(t *testing.T) {
+	claimMappings := map[string]string{"email": "email"}
+	listClaimMappings := map[string]string{"groups": "groups"}
+
+	cases := []struct {
+		selector   string
+		wantOffset int
+		wantToken  string
+	}{
+		{`value.email == "list.grops" and "a" in list.grops`, 39, "list.grops"},
+		{"value.email == `value.emial` or value.emial == \"a\"", 32, "value.emial"},
+		{`value.email == "a" or value.email == "b" or value.emial == "c"`, 44, "value.emial"},
+		{`"a" in list["grops"]`, 7, `list["grops"]`},
+		{`"/value/emial" == "a"`, 0, `"/value/emial"`},
+		{`(all list.groups as g { g != "a" }) and g == "b"`, 40, "g"},
+	}
+
+	for _, tc := range cases {
+		errs := validateAccessSelector(tc.selector, claimMappings, listClaimMappings)
+		if len(errs) != 1 {
+			t.Errorf("%s: got errors %v, want one", tc.selector, errs)
+			continue
+		}
+
+		if errs[0].Offset != tc.wantOffset || errs[0].Token != tc.wantToken {
+			t.Errorf("%s: got %q at %d, want %q at %d", tc.selector, errs[0].Token, errs[0].Offset, tc.wantToken, tc.wantOffset)
+		}
+	}
+}
+
+func TestAccessSelectorErrorDetail(t *testing.T) {
+	selector := `"admin" in list.grops`
+	e := accessSelectorError{Offset: 11, Token: "list.grops", Message: "Unknown claim"}
+
+	want := "Unknown claim\n\n  \"admin\" in list.grops\n             ^^^^^^^^^^"
+	if got := e.Detail(selector); got != want {
+		t.Errorf("got detail\n%s\nwant\n%s", got, want)
+	}
+}
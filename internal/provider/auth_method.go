@@ -0,0 +1,185 @@
+package waypoint
+
+import (
+	"context"
+	"fmt"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// authMethodKind describes a type of auth method of the Waypoint server,
+// managed by the waypoint_auth_method_<method> resource that
+// resourceAuthMethod builds from it.
+type authMethodKind struct {
+	// method is the type of the auth method, as returned by authMethodType.
+	method string
+
+	// minServerVersion is the first Waypoint server version supporting
+	// the method.
+	minServerVersion string
+
+	// schema holds the attributes specific to the method. They are added to
+	// the name, display_name, description and accessor_selector attributes
+	// shared by every method, and may override their description.
+	schema map[string]*schema.Schema
+
+	// expand sets the configuration specific to the method in am from d.
+	expand func(d *schema.ResourceData, am *gen.AuthMethod) diag.Diagnostics
+
+	// flatten sets the attributes specific to the method in d from am,
+	// which is known to be of the method.
+	flatten func(d *schema.ResourceData, am *gen.AuthMethod) diag.Diagnostics
+}
+
+// resourceName returns the name of the resource managing the method.
+func (k authMethodKind) resourceName() string {
+	return "waypoint_auth_method_" + k.method
+}
+
+// resourceAuthMethod returns the resource managing the auth methods of kind.
+func resourceAuthMethod(kind authMethodKind, description string) *schema.Resource {
+	resourceSchema := map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "Name of the auth method",
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Friendly display name of the auth method",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Description of the auth method",
+		},
+		"accessor_selector": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Selector expression, in the go-bexpr syntax, that users must match to log in with the auth method",
+		},
+	}
+
+	for key, value := range kind.schema {
+		resourceSchema[key] = value
+	}
+
+	return &schema.Resource{
+		Description: description,
+
+		CreateContext: kind.upsert,
+		ReadContext:   kind.read,
+		UpdateContext: kind.upsert,
+		DeleteContext: kind.delete,
+
+		Schema: resourceSchema,
+	}
+}
+
+func (k authMethodKind) upsert(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	if diags := m.(*WaypointClient).requireServerVersion(k.resourceName(), k.minServerVersion); diags.HasError() {
+		return diags
+	}
+
+	am := &gen.AuthMethod{
+		Name:           d.Get("name").(string),
+		DisplayName:    d.Get("display_name").(string),
+		Description:    d.Get("description").(string),
+		AccessSelector: d.Get("accessor_selector").(string),
+	}
+
+	if diags := k.expand(d, am); diags.HasError() {
+		return diags
+	}
+
+	resp, err := wp.GRPCClient().UpsertAuthMethod(ctx, &gen.UpsertAuthMethodRequest{AuthMethod: am})
+	if err != nil {
+		return diag.Errorf("Error upserting the %s auth method: %s", am.Name, err)
+	}
+
+	d.SetId(resp.AuthMethod.Name)
+
+	tflog.Trace(ctx, "upserted an auth method", map[string]interface{}{"name": am.Name, "method": k.method})
+
+	return k.read(ctx, d, m)
+}
+
+func (k authMethodKind) read(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	resp, err := wp.GRPCClient().GetAuthMethod(ctx, &gen.GetAuthMethodRequest{
+		AuthMethod: &gen.Ref_AuthMethod{Name: d.Id()},
+	})
+	if status.Code(err) == codes.NotFound {
+		tflog.Warn(ctx, "auth method not found, removing it from the state", map[string]interface{}{"name": d.Id()})
+		d.SetId("")
+		return nil
+	}
+	if err != nil {
+		return diag.Errorf("Error retrieving the %s auth method: %s", d.Id(), err)
+	}
+
+	if diags := k.checkType(resp.AuthMethod); diags.HasError() {
+		return diags
+	}
+
+	d.Set("name", resp.AuthMethod.Name)
+	d.Set("display_name", resp.AuthMethod.DisplayName)
+	d.Set("description", resp.AuthMethod.Description)
+	d.Set("accessor_selector", resp.AuthMethod.AccessSelector)
+
+	return k.flatten(d, resp.AuthMethod)
+}
+
+func (k authMethodKind) delete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	_, err := wp.GRPCClient().DeleteAuthMethod(ctx, &gen.DeleteAuthMethodRequest{
+		AuthMethod: &gen.Ref_AuthMethod{Name: d.Id()},
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		return diag.Errorf("Error deleting the %s auth method: %s", d.Id(), err)
+	}
+
+	return nil
+}
+
+// checkType returns an error when am is not of the method, which happens
+// when an auth method of the same name but another type replaced it
+// outside of Terraform.
+func (k authMethodKind) checkType(am *gen.AuthMethod) diag.Diagnostics {
+	method := authMethodType(am)
+	if method == k.method {
+		return nil
+	}
+
+	return diag.Diagnostics{
+		{
+			Severity: diag.Error,
+			Summary:  "Unexpected auth method type",
+			Detail: fmt.Sprintf(
+				"The auth method %s is of type %s, but %s manages %s auth methods. It was likely replaced outside of Terraform; remove it from the state, or delete it from the Waypoint server, before applying again.",
+				am.Name, method, k.resourceName(), k.method),
+		},
+	}
+}
+
+// authMethodType returns the type of am, such as oidc.
+func authMethodType(am *gen.AuthMethod) string {
+	switch am.Method.(type) {
+	case *gen.AuthMethod_Oidc:
+		return "oidc"
+	case nil:
+		return "unset"
+	default:
+		return "unknown"
+	}
+}
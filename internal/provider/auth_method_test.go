@@ -0,0 +1,96 @@
+package waypoint
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestAuthMethodKindCheckType(t *testing.T) {
+	cases := []struct {
+		name      string
+		am        *gen.AuthMethod
+		wantError string
+	}{
+		{"oidc", &gen.AuthMethod{Name: "okta", Method: &gen.AuthMethod_Oidc{Oidc: &gen.AuthMethod_OIDC{}}}, ""},
+		{"unset", &gen.AuthMethod{Name: "okta"}, "The auth method okta is of type unset, but waypoint_auth_method_oidc manages oidc auth methods"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			diags := authMethodOidc().checkType(tc.am)
+
+			if tc.wantError == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected error: %v", diags)
+				}
+				return
+			}
+
+			if !diags.HasError() {
+				t.Fatalf("got no error, want %q", tc.wantError)
+			}
+
+			if !strings.Contains(diags[0].Detail, tc.wantError) {
+				t.Errorf("got error %q, want %q", diags[0].Detail, tc.wantError)
+			}
+		})
+	}
+}
+
+func TestResourceAuthMethodSchema(t *testing.T) {
+	r := resourceAuthMethod(authMethodOidc(), "")
+
+	for _, key := range []string{"name", "display_name", "description", "accessor_selector", "client_id"} {
+		if _, ok := r.Schema[key]; !ok {
+			t.Errorf("attribute %s is missing", key)
+		}
+	}
+
+	// Methods may describe the shared attributes in their own terms.
+	if !strings.Contains(r.Schema["accessor_selector"].Description, "claim_mappings") {
+		t.Errorf("the OIDC description of accessor_selector is not used: %q", r.Schema["accessor_selector"].Description)
+	}
+}
+
+func TestFlattenAuthMethodOidc(t *testing.T) {
+	r := resourceAuthMethodOidc()
+
+	d := schema.TestResourceDataRaw(t, r.Schema, map[string]interface{}{
+		"name":                  "okta",
+		"client_id":             "client",
+		"client_secret":         "secret",
+		"discovery_url":         "https://example.okta.com",
+		"allowed_redirect_urls": []interface{}{"https://waypoint.example.com/auth/oidc-callback"},
+	})
+
+	// The server may redact the secret, which must not reach the state.
+	am := &gen.AuthMethod{
+		Name: "okta",
+		Method: &gen.AuthMethod_Oidc{Oidc: &gen.AuthMethod_OIDC{
+			ClientId:            "other-client",
+			DiscoveryUrl:        "https://example.okta.com",
+			AllowedRedirectUris: []string{"https://waypoint.example.com/auth/oidc-callback"},
+			ListClaimMappings:   map[string]string{"groups": "groups"},
+		}},
+	}
+
+	if diags := flattenAuthMethodOidc(d, am); diags.HasError() {
+		t.Fatalf("unexpected error: %v", diags)
+	}
+
+	if v := d.Get("client_secret"); v != "secret" {
+		t.Errorf("got client_secret %q, want it left as configured", v)
+	}
+
+	if v := d.Get("client_id"); v != "other-client" {
+		t.Errorf("got client_id %q, want %q", v, "other-client")
+	}
+
+	if v := d.Get("list_claim_mappings").(map[string]interface{}); !reflect.DeepEqual(v, map[string]interface{}{"groups": "groups"}) {
+		t.Errorf("got list_claim_mappings %v, want groups", v)
+	}
+}
@@ -1,13 +1,25 @@
 package waypoint
 
 import (
+	"context"
 	"fmt"
+	"strings"
+	"sync"
+
 	//gofastly "github.com/fastly/go-fastly/v6/fastly"
 	waypoint "github.com/hashicorp-dev-advocates/waypoint-client/pkg/client"
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/go-version"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	//"github.com/hashicorp/terraform-plugin-sdk/v2/helper/logging"
 )
 
+// Minimum Waypoint server versions required by the resources of this provider.
+const (
+	minServerVersionAuthMethodOidc = "0.5.0"
+	minServerVersionRunnerProfile  = "0.7.0"
+)
+
 type Config struct {
 	Token        string
 	WaypointAddr string
@@ -15,13 +27,17 @@ type Config struct {
 
 type WaypointClient struct {
 	conn waypoint.Waypoint
+
+	// serverInfo is the version information reported by the Waypoint server
+	// when the provider was configured.
+	serverInfo *gen.VersionInfo
 }
 
-func (c *Config) Client() (*WaypointClient, diag.Diagnostics) {
+func (c *Config) Client() (*WaypointClient, error) {
 	var client WaypointClient
 
 	if c.Token == "" {
-		return nil, diag.FromErr(fmt.Errorf("[Err] No Waypoint token set"))
+		return nil, fmt.Errorf("[Err] No Waypoint token set")
 	}
 
 	waypointClientConfig := waypoint.DefaultConfig()
@@ -30,9 +46,90 @@ func (c *Config) Client() (*WaypointClient, diag.Diagnostics) {
 
 	waypointClient, err := waypoint.New(waypointClientConfig)
 	if err != nil {
-		return nil, diag.FromErr(err)
+		return nil, err
+	}
+
+	serverInfo, err := waypointClient.GetVersionInfo(context.TODO())
+	if err != nil {
+		return nil, fmt.Errorf("[Err] Unable to retrieve Waypoint server version: %w", err)
 	}
 
 	client.conn = waypointClient
+	client.serverInfo = serverInfo
 	return &client, nil
 }
+
+// sharedClient creates the Waypoint client of the SDKv2 and framework
+// providers, which are configured separately with the same settings, once.
+type sharedClient struct {
+	mu     sync.Mutex
+	config Config
+	client *WaypointClient
+}
+
+// Client returns the client created for config, creating it when config
+// differs from the one the current client was created for.
+func (s *sharedClient) Client(config Config) (*WaypointClient, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.client != nil && s.config == config {
+		return s.client, nil
+	}
+
+	client, err := config.Client()
+	if err != nil {
+		return nil, err
+	}
+
+	s.config = config
+	s.client = client
+
+	return client, nil
+}
+
+// serverVersion returns the parsed version of the configured Waypoint server,
+// or nil if the server does not report a version.
+func (c *WaypointClient) serverVersion() *version.Version {
+	if c.serverInfo == nil {
+		return nil
+	}
+
+	// The version may be blank, or carry build metadata such as
+	// "v0.9.1 (a1b2c3d4)", so only the leading semver is parsed.
+	fields := strings.Fields(c.serverInfo.Version)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	v, err := version.NewVersion(fields[0])
+	if err != nil {
+		return nil
+	}
+
+	return v
+}
+
+// requireServerVersion returns an error diagnostic when the configured
+// Waypoint server is older than minimum. Servers that hide their version
+// are assumed to support the feature.
+func (c *WaypointClient) requireServerVersion(feature string, minimum string) diag.Diagnostics {
+	current := c.serverVersion()
+	if current == nil {
+		return nil
+	}
+
+	if current.LessThan(version.Must(version.NewVersion(minimum))) {
+		return diag.Diagnostics{
+			{
+				Severity: diag.Error,
+				Summary:  "Unsupported Waypoint server version",
+				Detail: fmt.Sprintf(
+					"%s requires Waypoint server version %s or newer, but the configured server is running version %s. Upgrade the Waypoint server to use this feature.",
+					feature, minimum, current),
+			},
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,32 @@
+package waypoint
+
+import (
+	"testing"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+)
+
+func TestRequireServerVersion(t *testing.T) {
+	cases := []struct {
+		serverVersion string
+		minimum       string
+		wantError     bool
+	}{
+		{"v0.9.1", "0.7.0", false},
+		{"v0.7.0", "0.7.0", false},
+		{"v0.6.3", "0.7.0", true},
+		{"v0.6.3 (a1b2c3d4)", "0.7.0", true},
+		{"0.10.0-dev", "0.7.0", false},
+		{"", "0.7.0", false},
+		{"unknown", "0.7.0", false},
+	}
+
+	for _, tc := range cases {
+		c := &WaypointClient{serverInfo: &gen.VersionInfo{Version: tc.serverVersion}}
+
+		diags := c.requireServerVersion("waypoint_runner_profile", tc.minimum)
+		if diags.HasError() != tc.wantError {
+			t.Errorf("server version %q, minimum %q: got error %t, want %t", tc.serverVersion, tc.minimum, diags.HasError(), tc.wantError)
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package waypoint
+
+import (
+	"context"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceAuthMethod() *schema.Resource {
+	dataSourceSchema := authMethodDataSourceSchema()
+	dataSourceSchema["name"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Required:    true,
+		Description: "Name of the auth method",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceAuthMethodRead,
+		Description: "A data source to read an auth method of the Waypoint server, whichever way it was created",
+		Schema:      dataSourceSchema,
+	}
+}
+
+func dataSourceAuthMethodRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	if diags := m.(*WaypointClient).requireServerVersion("waypoint_auth_method", minServerVersionAuthMethodOidc); diags.HasError() {
+		return diags
+	}
+
+	name := d.Get("name").(string)
+
+	resp, err := wp.GRPCClient().GetAuthMethod(ctx, &gen.GetAuthMethodRequest{
+		AuthMethod: &gen.Ref_AuthMethod{Name: name},
+	})
+	if err != nil {
+		return diag.Errorf("Error retrieving the %s auth method: %s", name, err)
+	}
+
+	d.SetId(resp.AuthMethod.Name)
+
+	for key, value := range flattenAuthMethod(resp.AuthMethod) {
+		if err := d.Set(key, value); err != nil {
+			return diag.FromErr(err)
+		}
+	}
+
+	return nil
+}
+
+// authMethodDataSourceSchema returns the attributes read from an auth
+// method, which are the same for a single auth method and each element of
+// a list of them. Secrets, such as the OIDC client secret, are left out.
+func authMethodDataSourceSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Name of the auth method",
+		},
+		"display_name": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Friendly display name of the auth method",
+		},
+		"description": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Description of the auth method",
+		},
+		"method": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Type of the auth method, such as `oidc`",
+		},
+		"accessor_selector": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Selector expression that users must match to log in with the auth method",
+		},
+		"oidc": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Configuration of an OIDC auth method, empty for other types",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"client_id": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Client ID of the OIDC provider",
+					},
+					"discovery_url": {
+						Type:        schema.TypeString,
+						Computed:    true,
+						Description: "Discovery URL of the OIDC provider",
+					},
+					"discovery_ca_pem":      computedStringList("CA certificates trusted to validate the discovery URL"),
+					"allowed_redirect_urls": computedStringList("Allowed URIs for auth redirection"),
+					"signing_algs":          computedStringList("Signing algorithms supported by the OIDC provider"),
+					"scopes":                computedStringList("Claims scopes requested"),
+					"auds":                  computedStringList("Audience claims required"),
+					"claim_mappings": {
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Description: "Mapping of claims to the names available to the accessor selector as `value.<name>`",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"list_claim_mappings": {
+						Type:        schema.TypeMap,
+						Computed:    true,
+						Description: "Mapping of list claims to the names available to the accessor selector as `list.<name>`",
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func computedStringList(description string) *schema.Schema {
+	return &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: description,
+		Elem: &schema.Schema{
+			Type: schema.TypeString,
+		},
+	}
+}
+
+// flattenAuthMethod returns the attributes of authMethodDataSourceSchema
+// for am.
+func flattenAuthMethod(am *gen.AuthMethod) map[string]interface{} {
+	authMethod := map[string]interface{}{
+		"name":              am.Name,
+		"display_name":      am.DisplayName,
+		"description":       am.Description,
+		"accessor_selector": am.AccessSelector,
+		"method":            authMethodType(am),
+		"oidc":              []interface{}{},
+	}
+
+	if oidc := am.GetOidc(); oidc != nil {
+		authMethod["oidc"] = []interface{}{map[string]interface{}{
+			"client_id":             oidc.ClientId,
+			"discovery_url":         oidc.DiscoveryUrl,
+			"discovery_ca_pem":      oidc.DiscoveryCaPem,
+			"allowed_redirect_urls": oidc.AllowedRedirectUris,
+			"signing_algs":          oidc.SigningAlgs,
+			"scopes":                oidc.Scopes,
+			"auds":                  oidc.Auds,
+			"claim_mappings":        oidc.ClaimMappings,
+			"list_claim_mappings":   oidc.ListClaimMappings,
+		}}
+	}
+
+	return authMethod
+}
@@ -0,0 +1,145 @@
+package waypoint
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceWaypointAuthMethod(t *testing.T) {
+	amName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAuthMethod(amName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_auth_method.test", "name", amName),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_auth_method.test", "display_name", "Example SSO"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_auth_method.test", "method", "oidc"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_auth_method.test", "accessor_selector", "mycompany in list.groups"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_auth_method.test", "oidc.0.client_id", "060d0801-6fv7-4b03-ad59-b6397e2hc4ad"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_auth_method.test", "oidc.0.allowed_redirect_urls.#", "1"),
+					resource.TestCheckNoResourceAttr(
+						"data.waypoint_auth_method.test", "oidc.0.client_secret"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWaypointAuthMethods(t *testing.T) {
+	amName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceAuthMethods(amName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckTypeSetElemAttr(
+						"data.waypoint_auth_methods.test", "names.*", amName),
+					resource.TestCheckTypeSetElemNestedAttrs(
+						"data.waypoint_auth_methods.test", "auth_methods.*", map[string]string{
+							"name":         amName,
+							"display_name": "Example SSO",
+							"method":       "oidc",
+						}),
+				),
+			},
+		},
+	})
+}
+
+func TestFlattenAuthMethod(t *testing.T) {
+	am := &gen.AuthMethod{
+		Name:           "okta",
+		DisplayName:    "Okta",
+		AccessSelector: `"admin" in list.groups`,
+		Method: &gen.AuthMethod_Oidc{Oidc: &gen.AuthMethod_OIDC{
+			ClientId:            "client",
+			ClientSecret:        "secret",
+			DiscoveryUrl:        "https://example.okta.com",
+			AllowedRedirectUris: []string{"https://waypoint.example.com/auth/oidc-callback"},
+			ListClaimMappings:   map[string]string{"groups": "groups"},
+		}},
+	}
+
+	got := flattenAuthMethod(am)
+
+	if got["method"] != "oidc" {
+		t.Errorf("got method %q, want %q", got["method"], "oidc")
+	}
+
+	oidc := got["oidc"].([]interface{})
+	if len(oidc) != 1 {
+		t.Fatalf("got %d oidc blocks, want 1", len(oidc))
+	}
+
+	block := oidc[0].(map[string]interface{})
+	if _, ok := block["client_secret"]; ok {
+		t.Errorf("the client secret must not be exposed")
+	}
+
+	if !reflect.DeepEqual(block["allowed_redirect_urls"], am.GetOidc().AllowedRedirectUris) {
+		t.Errorf("got allowed_redirect_urls %v, want %v", block["allowed_redirect_urls"], am.GetOidc().AllowedRedirectUris)
+	}
+
+	// Every attribute of the schema is set, so that d.Set clears the
+	// attributes of other method types.
+	for key := range authMethodDataSourceSchema() {
+		if _, ok := got[key]; !ok {
+			t.Errorf("attribute %s is not flattened", key)
+		}
+	}
+}
+
+func testAccAuthMethodOidcForDataSource(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_auth_method_oidc" "test" {
+  name              = %q
+  display_name      = "Example SSO"
+  client_id         = "060d0801-6fv7-4b03-ad59-b6397e2hc4ad"
+  client_secret     = "lFBsQ~Gb2E7p3Q3jr9jrWWw6aIQ5L3tYJ3wzLc1q"
+  discovery_url     = "https://login.microsoftonline.com/<insert-tenant-ID-here>/v2.0"
+  accessor_selector = "mycompany in list.groups"
+
+  allowed_redirect_urls = [
+    "https://localhost:9702/auth/oidc-callback",
+  ]
+
+  list_claim_mappings = {
+    "groups" = "groups"
+  }
+}
+`, name)
+}
+
+func testAccDataSourceAuthMethod(name string) string {
+	return testAccAuthMethodOidcForDataSource(name) + `
+data "waypoint_auth_method" "test" {
+  name = waypoint_auth_method_oidc.test.name
+}
+`
+}
+
+func testAccDataSourceAuthMethods(name string) string {
+	return testAccAuthMethodOidcForDataSource(name) + `
+data "waypoint_auth_methods" "test" {
+  depends_on = [waypoint_auth_method_oidc.test]
+}
+`
+}
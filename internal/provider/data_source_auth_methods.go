@@ -0,0 +1,67 @@
+package waypoint
+
+import (
+	"context"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func dataSourceAuthMethods() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceAuthMethodsRead,
+		Description: "A data source to list the auth methods of the Waypoint server",
+		Schema: map[string]*schema.Schema{
+			"names": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Names of the auth methods, sorted",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"auth_methods": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Auth methods, sorted by name",
+				Elem: &schema.Resource{
+					Schema: authMethodDataSourceSchema(),
+				},
+			},
+		},
+	}
+}
+
+func dataSourceAuthMethodsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	if diags := m.(*WaypointClient).requireServerVersion("waypoint_auth_methods", minServerVersionAuthMethodOidc); diags.HasError() {
+		return diags
+	}
+
+	resp, err := wp.GRPCClient().ListAuthMethods(ctx, &emptypb.Empty{})
+	if err != nil {
+		return diag.Errorf("Error listing the auth methods: %s", err)
+	}
+
+	authMethods := resp.AuthMethods
+	sort.Slice(authMethods, func(i, j int) bool {
+		return authMethods[i].Name < authMethods[j].Name
+	})
+
+	names := make([]string, len(authMethods))
+	flattened := make([]interface{}, len(authMethods))
+
+	for i, am := range authMethods {
+		names[i] = am.Name
+		flattened[i] = flattenAuthMethod(am)
+	}
+
+	d.SetId("auth_methods")
+	d.Set("names", names)
+	d.Set("auth_methods", flattened)
+
+	return nil
+}
@@ -0,0 +1,204 @@
+package waypoint
+
+import (
+	"context"
+	"strings"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+)
+
+func dataSourceBuilds() *schema.Resource {
+	operationSchema := applicationOperationSchema("builds")
+	operationSchema["builds"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Builds of the application, most recent first",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the build",
+				},
+				"sequence": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Sequence number of the build for the application",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Status of the build operation, one of `RUNNING`, `SUCCESS`, `ERROR` or `UNKNOWN`",
+				},
+				"component": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the builder plugin, such as `docker` or `pack`",
+				},
+				"artifact_json": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "JSON representation of the artifact produced by the builder plugin",
+				},
+				"labels": {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Description: "Labels attached to the build",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"job_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the job that created the build",
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceBuildsRead,
+		Description: "A data source to read the builds of a Waypoint application",
+		Schema:      operationSchema,
+	}
+}
+
+func dataSourceBuildsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	var builds []*gen.Build
+
+	if _, ok := d.GetOk("sequence"); ok {
+		build, err := wp.GRPCClient().GetBuild(ctx, &gen.GetBuildRequest{
+			Ref: operationSequenceRef(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		builds = []*gen.Build{build}
+	} else {
+		lbr, err := wp.GRPCClient().ListBuilds(ctx, &gen.ListBuildsRequest{
+			Application: applicationRef(d),
+			Workspace:   workspaceRef(d),
+			Order:       operationOrder(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		builds = lbr.Builds
+	}
+
+	d.SetId(applicationId(d))
+	d.Set("builds", flattenBuilds(builds))
+
+	return nil
+}
+
+func flattenBuilds(builds []*gen.Build) []interface{} {
+	bs := make([]interface{}, len(builds))
+
+	for b, build := range builds {
+		bld := make(map[string]interface{})
+
+		bld["id"] = build.Id
+		bld["sequence"] = int(build.Sequence)
+		bld["status"] = build.GetStatus().GetState().String()
+		bld["component"] = build.GetComponent().GetName()
+		bld["artifact_json"] = build.GetArtifact().GetArtifactJson()
+		bld["labels"] = build.Labels
+		bld["job_id"] = build.JobId
+		bs[b] = bld
+	}
+	return bs
+}
+
+// applicationOperationSchema returns the arguments shared by the data sources
+// reading the builds, deployments and releases of an application.
+func applicationOperationSchema(operations string) map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"project_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the Waypoint project",
+		},
+		"application_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the application in the Waypoint project",
+		},
+		"workspace_name": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Default:     "default",
+			Description: "The workspace to read " + operations + " from",
+		},
+		"latest": {
+			Type:          schema.TypeBool,
+			Optional:      true,
+			ConflictsWith: []string{"sequence"},
+			Description:   "Only return the most recent of the " + operations,
+		},
+		"sequence": {
+			Type:          schema.TypeInt,
+			Optional:      true,
+			ConflictsWith: []string{"latest"},
+			ValidateFunc:  validation.IntAtLeast(1),
+			Description:   "Only return the " + strings.TrimSuffix(operations, "s") + " with this sequence number",
+		},
+	}
+}
+
+func applicationRef(d *schema.ResourceData) *gen.Ref_Application {
+	return &gen.Ref_Application{
+		Project:     d.Get("project_name").(string),
+		Application: d.Get("application_name").(string),
+	}
+}
+
+func workspaceRef(d *schema.ResourceData) *gen.Ref_Workspace {
+	return &gen.Ref_Workspace{
+		Workspace: d.Get("workspace_name").(string),
+	}
+}
+
+// operationSequenceRef references the operation selected by the sequence
+// argument.
+func operationSequenceRef(d *schema.ResourceData) *gen.Ref_Operation {
+	return &gen.Ref_Operation{
+		Target: &gen.Ref_Operation_Sequence{
+			Sequence: &gen.Ref_OperationSeq{
+				Application: applicationRef(d),
+				Number:      uint64(d.Get("sequence").(int)),
+			},
+		},
+	}
+}
+
+// operationOrder lists operations most recent first, limited to a single
+// operation when the latest argument is set.
+func operationOrder(d *schema.ResourceData) *gen.OperationOrder {
+	order := &gen.OperationOrder{
+		Order: gen.OperationOrder_START_TIME,
+		Desc:  true,
+	}
+
+	if d.Get("latest").(bool) {
+		order.Limit = 1
+	}
+
+	return order
+}
+
+func applicationId(d *schema.ResourceData) string {
+	return strings.Join([]string{
+		d.Get("project_name").(string),
+		d.Get("application_name").(string),
+		d.Get("workspace_name").(string),
+	}, "/")
+}
@@ -0,0 +1,67 @@
+package waypoint
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceWaypointBuildsLatest(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBuildsLatest(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_builds.test", "builds.#", "1"),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_builds.test", "builds.0.id", regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_builds.test", "builds.0.sequence", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_builds.test", "builds.0.status", "SUCCESS"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWaypointBuildsSequence(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceBuildsSequence(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_builds.test", "builds.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_builds.test", "builds.0.sequence", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceBuildsLatest() string {
+	return `
+data "waypoint_builds" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  latest           = true
+}
+`
+}
+
+func testAccDataSourceBuildsSequence() string {
+	return `
+data "waypoint_builds" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  sequence         = 1
+}
+`
+}
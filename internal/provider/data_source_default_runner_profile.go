@@ -0,0 +1,52 @@
+package waypoint
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func dataSourceDefaultRunnerProfile() *schema.Resource {
+	// The attributes are those of waypoint_runner_profile, with the ID
+	// looked up rather than given.
+	dataSourceSchema := dataSourceRunnerProfile().Schema
+	dataSourceSchema["id"] = &schema.Schema{
+		Type:        schema.TypeString,
+		Computed:    true,
+		Description: "Computed ID of the default runner profile.",
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDefaultRunnerProfileRead,
+		Description: "A data source to read the waypoint runner profile that is the default for new projects",
+		Schema:      dataSourceSchema,
+	}
+}
+
+func dataSourceDefaultRunnerProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	if diags := m.(*WaypointClient).requireServerVersion("waypoint_default_runner_profile", minServerVersionRunnerProfile); diags.HasError() {
+		return diags
+	}
+
+	resp, err := wp.GRPCClient().ListOnDemandRunnerConfigs(ctx, &emptypb.Empty{})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, config := range resp.Configs {
+		if config.Default {
+			d.SetId(config.Id)
+
+			diags := resourceRunnerProfileRead(ctx, d, m)
+			setFlatTargetRunner(d)
+
+			return diags
+		}
+	}
+
+	return diag.Errorf("no runner profile is the default")
+}
@@ -0,0 +1,53 @@
+package waypoint
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccDataSourceDefaultRunnerProfile(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDefaultRunnerProfile(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttrPair(
+						"data.waypoint_default_runner_profile.default", "id", "waypoint_runner_profile.default", "id"),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_default_runner_profile.default", "profile_name", regexp.MustCompile(rName)),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_default_runner_profile.default", "default", "true"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDefaultRunnerProfile(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "default" {
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
+}
+
+data "waypoint_default_runner_profile" "default" {
+  depends_on = [
+    waypoint_runner_profile.default
+  ]
+}
+`, name)
+}
@@ -0,0 +1,129 @@
+package waypoint
+
+import (
+	"context"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceDeployments() *schema.Resource {
+	operationSchema := applicationOperationSchema("deployments")
+	operationSchema["deployments"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Deployments of the application, most recent first",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the deployment",
+				},
+				"sequence": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Sequence number of the deployment for the application",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Status of the deploy operation, one of `RUNNING`, `SUCCESS`, `ERROR` or `UNKNOWN`",
+				},
+				"physical_state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Physical state of the deployment, one of `PENDING`, `CREATED`, `DESTROYED` or `UNKNOWN`",
+				},
+				"component": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the platform plugin, such as `kubernetes` or `nomad`",
+				},
+				"artifact_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the pushed artifact that was deployed",
+				},
+				"url": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "URL of the deployment, if the URL service or the platform provides one",
+				},
+				"labels": {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Description: "Labels attached to the deployment",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"job_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the job that created the deployment",
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceDeploymentsRead,
+		Description: "A data source to read the deployments of a Waypoint application",
+		Schema:      operationSchema,
+	}
+}
+
+func dataSourceDeploymentsRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	var deployments []*gen.Deployment
+
+	if _, ok := d.GetOk("sequence"); ok {
+		deployment, err := wp.GRPCClient().GetDeployment(ctx, &gen.GetDeploymentRequest{
+			Ref: operationSequenceRef(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		deployments = []*gen.Deployment{deployment}
+	} else {
+		ldr, err := wp.GRPCClient().ListDeployments(ctx, &gen.ListDeploymentsRequest{
+			Application: applicationRef(d),
+			Workspace:   workspaceRef(d),
+			Order:       operationOrder(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		deployments = ldr.Deployments
+	}
+
+	d.SetId(applicationId(d))
+	d.Set("deployments", flattenDeployments(deployments))
+
+	return nil
+}
+
+func flattenDeployments(deployments []*gen.Deployment) []interface{} {
+	ds := make([]interface{}, len(deployments))
+
+	for i, deployment := range deployments {
+		dep := make(map[string]interface{})
+
+		dep["id"] = deployment.Id
+		dep["sequence"] = int(deployment.Sequence)
+		dep["status"] = deployment.GetStatus().GetState().String()
+		dep["physical_state"] = deployment.State.String()
+		dep["component"] = deployment.GetComponent().GetName()
+		dep["artifact_id"] = deployment.ArtifactId
+		dep["url"] = deployment.Url
+		dep["labels"] = deployment.Labels
+		dep["job_id"] = deployment.JobId
+		ds[i] = dep
+	}
+	return ds
+}
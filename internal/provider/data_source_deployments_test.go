@@ -0,0 +1,67 @@
+package waypoint
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceWaypointDeploymentsLatest(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeploymentsLatest(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_deployments.test", "deployments.#", "1"),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_deployments.test", "deployments.0.id", regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_deployments.test", "deployments.0.sequence", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_deployments.test", "deployments.0.status", "SUCCESS"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWaypointDeploymentsSequence(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceDeploymentsSequence(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_deployments.test", "deployments.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_deployments.test", "deployments.0.sequence", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceDeploymentsLatest() string {
+	return `
+data "waypoint_deployments" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  latest           = true
+}
+`
+}
+
+func testAccDataSourceDeploymentsSequence() string {
+	return `
+data "waypoint_deployments" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  sequence         = 1
+}
+`
+}
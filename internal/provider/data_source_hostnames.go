@@ -0,0 +1,99 @@
+package waypoint
+
+import (
+	"context"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceHostnames() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceHostnamesRead,
+		Description: "A data source to list Waypoint URL service hostnames",
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"application_name"},
+				Description:  "Only list hostnames routing to an application in this project",
+			},
+			"application_name": {
+				Type:         schema.TypeString,
+				Optional:     true,
+				RequiredWith: []string{"project_name"},
+				Description:  "Only list hostnames routing to this application",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "The workspace of the application when filtering by application",
+			},
+			"hostnames": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Hostnames registered with the URL service",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"hostname": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The hostname alone, such as `fabulous-panda`",
+						},
+						"fqdn": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Fully qualified domain name of the hostname",
+						},
+						"target_labels": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "The raw label targets given to the URL service",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceHostnamesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	lhr := &gen.ListHostnamesRequest{}
+	id := "all"
+
+	if d.Get("application_name").(string) != "" {
+		lhr.Target = hostnameTarget(d)
+		id = applicationId(d)
+	}
+
+	hostnames, err := wp.GRPCClient().ListHostnames(ctx, lhr)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(id)
+	d.Set("hostnames", flattenHostnames(hostnames.Hostnames))
+
+	return nil
+}
+
+func flattenHostnames(hostnames []*gen.Hostname) []interface{} {
+	hosts := make([]interface{}, len(hostnames))
+
+	for h, hostname := range hostnames {
+		host := make(map[string]interface{})
+
+		host["hostname"] = hostname.Hostname
+		host["fqdn"] = hostname.Fqdn
+		host["target_labels"] = hostname.TargetLabels
+		hosts[h] = host
+	}
+	return hosts
+}
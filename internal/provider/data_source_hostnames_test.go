@@ -0,0 +1,49 @@
+package waypoint
+
+import (
+	"fmt"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceWaypointHostnames(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceHostnames(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_hostnames.test", "hostnames.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_hostnames.test", "hostnames.0.hostname", rName),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_hostnames.test", "hostnames.0.fqdn", regexp.MustCompile("^"+rName+"\\.")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceHostnames(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_hostname" "test" {
+  hostname         = "%s"
+  project_name     = "example"
+  application_name = "example-nodejs"
+}
+
+data "waypoint_hostnames" "test" {
+  project_name     = waypoint_hostname.test.project_name
+  application_name = waypoint_hostname.test.application_name
+  depends_on = [
+	waypoint_hostname.test
+  ]
+}
+`, name)
+}
@@ -2,6 +2,8 @@ package waypoint
 
 import (
 	"context"
+	"time"
+
 	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
@@ -78,7 +80,7 @@ func dataSourceProject() *schema.Resource {
 						"git_poll_interval_seconds": &schema.Schema{
 							Type:        schema.TypeInt,
 							Computed:    true,
-							Description: "Interval at which Waypoint should poll git repository for changes",
+							Description: "Interval at which Waypoint should poll git repository for changes, `0` when polling is disabled",
 						},
 						"file_change_signal": &schema.Schema{
 							Type:        schema.TypeString,
@@ -96,7 +98,55 @@ func dataSourceProject() *schema.Resource {
 			"app_status_poll_seconds": &schema.Schema{
 				Type:        schema.TypeInt,
 				Computed:    true,
-				Description: "Application status poll interval in seconds",
+				Description: "Application status poll interval in seconds, `0` when polling is disabled",
+			},
+			"waypoint_hcl": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Contents of the waypoint.hcl file of the project, used when its git repository has none",
+			},
+			"waypoint_hcl_format": &schema.Schema{
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Syntax of `waypoint_hcl`, `hcl` or `json`",
+			},
+			"data_source_poll": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Polling of the git repository of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether Waypoint polls the git repository",
+						},
+						"interval": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Interval between polls",
+						},
+					},
+				},
+			},
+			"status_report_poll": &schema.Schema{
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Polling of the status of the applications of the project",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"enabled": &schema.Schema{
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether Waypoint polls the status of the applications",
+						},
+						"interval": &schema.Schema{
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Interval between status reports",
+						},
+					},
+				},
 			},
 			"git_auth_basic": &schema.Schema{
 				Type:        schema.TypeList,
@@ -151,12 +201,74 @@ func dataSourceProject() *schema.Resource {
 }
 
 func dataSourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	client := m.(*WaypointClient).conn
+
+	projectName := d.Get("project_name").(string)
+	project, err := client.GetProject(context.TODO(), projectName)
+	if err != nil {
+		return diag.Errorf("Error retrieving the %s project", projectName)
+	}
+
+	d.SetId(project.Name)
 
-	var diags diag.Diagnostics
+	d.Set("remote_runners_enabled", project.RemoteEnabled)
+	d.Set("waypoint_hcl", string(project.WaypointHcl))
+	d.Set("waypoint_hcl_format", flattenWaypointHclFormat(project.WaypointHclFormat))
+
+	applications := flattenApplications(project.Applications)
+	d.Set("applications", applications)
+
+	variables := flattenVariables(project.Variables)
+	d.Set("project_variables", variables)
+
+	dataSourceGitSlice := map[string]interface{}{}
+	dataSourceGitSlice["git_url"] = project.DataSource.GetGit().Url
+	dataSourceGitSlice["git_path"] = project.DataSource.GetGit().Path
+	dataSourceGitSlice["git_ref"] = project.DataSource.GetGit().Ref
+	dataSourceGitSlice["ignore_changes_outside_path"] = project.DataSource.GetGit().GetIgnoreChangesOutsidePath()
+	dataSourceGitSlice["file_change_signal"] = project.FileChangeSignal
+
+	dataSourceGitSlice["git_poll_interval_seconds"] = 0
+	if project.DataSourcePoll.GetEnabled() {
+		dpi, _ := time.ParseDuration(project.DataSourcePoll.Interval)
+		dataSourceGitSlice["git_poll_interval_seconds"] = int(dpi / time.Second)
+	}
+	d.Set("data_source_git", []interface{}{dataSourceGitSlice})
+
+	d.Set("data_source_poll", []interface{}{map[string]interface{}{
+		"enabled":  project.DataSourcePoll.GetEnabled(),
+		"interval": project.DataSourcePoll.GetInterval(),
+	}})
+
+	gitAuthBasicSlice := map[string]interface{}{}
+	gitAuthSshSlice := map[string]interface{}{}
+
+	gitAuth := project.DataSource.Source.(*gen.Job_DataSource_Git).Git.Auth
+	switch gitAuth.(type) {
+	case *gen.Job_Git_Basic_:
+		gitAuthBasicSlice["username"] = gitAuth.(*gen.Job_Git_Basic_).Basic.Username
+		gitAuthBasicSlice["password"] = gitAuth.(*gen.Job_Git_Basic_).Basic.Password
+		d.Set("git_auth_basic", []interface{}{gitAuthBasicSlice})
+	case *gen.Job_Git_Ssh:
+		gitAuthSshSlice["git_user"] = gitAuth.(*gen.Job_Git_Ssh).Ssh.User
+		gitAuthSshSlice["passphrase"] = gitAuth.(*gen.Job_Git_Ssh).Ssh.Password
+		gitAuthSshSlice["ssh_private_key"] = string(gitAuth.(*gen.Job_Git_Ssh).Ssh.PrivateKeyPem)
+		d.Set("git_auth_ssh", []interface{}{gitAuthSshSlice})
+	}
+
+	d.Set("app_status_poll_seconds", 0)
+	if project.StatusReportPoll.GetEnabled() {
+		asps := project.StatusReportPoll.Interval
+		aspsParse, _ := time.ParseDuration(asps)
+		d.Set("app_status_poll_seconds", aspsParse/time.Second)
+	}
 
-	resourceProjectRead(context.TODO(), d, m)
+	d.Set("status_report_poll", []interface{}{map[string]interface{}{
+		"enabled":  project.StatusReportPoll.GetEnabled(),
+		"interval": project.StatusReportPoll.GetInterval(),
+	}})
 
-	return diags
+	return nil
 }
 
 func flattenApplications(applications []*gen.Application) []interface{} {
@@ -13,8 +13,8 @@ func TestAccDataSourceWaypointProject(t *testing.T) {
 	rName := sdkacctest.RandomWithPrefix(providerName)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceProject(rName),
@@ -0,0 +1,129 @@
+package waypoint
+
+import (
+	"context"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceReleases() *schema.Resource {
+	operationSchema := applicationOperationSchema("releases")
+	operationSchema["releases"] = &schema.Schema{
+		Type:        schema.TypeList,
+		Computed:    true,
+		Description: "Releases of the application, most recent first",
+		Elem: &schema.Resource{
+			Schema: map[string]*schema.Schema{
+				"id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the release",
+				},
+				"sequence": {
+					Type:        schema.TypeInt,
+					Computed:    true,
+					Description: "Sequence number of the release for the application",
+				},
+				"status": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Status of the release operation, one of `RUNNING`, `SUCCESS`, `ERROR` or `UNKNOWN`",
+				},
+				"physical_state": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Physical state of the release, one of `PENDING`, `CREATED`, `DESTROYED` or `UNKNOWN`",
+				},
+				"component": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Name of the release manager plugin, such as `kubernetes`",
+				},
+				"deployment_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the deployment that was released",
+				},
+				"url": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "Public URL of the release",
+				},
+				"labels": {
+					Type:        schema.TypeMap,
+					Computed:    true,
+					Description: "Labels attached to the release",
+					Elem: &schema.Schema{
+						Type: schema.TypeString,
+					},
+				},
+				"job_id": {
+					Type:        schema.TypeString,
+					Computed:    true,
+					Description: "ID of the job that created the release",
+				},
+			},
+		},
+	}
+
+	return &schema.Resource{
+		ReadContext: dataSourceReleasesRead,
+		Description: "A data source to read the releases of a Waypoint application",
+		Schema:      operationSchema,
+	}
+}
+
+func dataSourceReleasesRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	var releases []*gen.Release
+
+	if _, ok := d.GetOk("sequence"); ok {
+		release, err := wp.GRPCClient().GetRelease(ctx, &gen.GetReleaseRequest{
+			Ref: operationSequenceRef(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		releases = []*gen.Release{release}
+	} else {
+		lrr, err := wp.GRPCClient().ListReleases(ctx, &gen.ListReleasesRequest{
+			Application: applicationRef(d),
+			Workspace:   workspaceRef(d),
+			Order:       operationOrder(d),
+		})
+		if err != nil {
+			return diag.FromErr(err)
+		}
+
+		releases = lrr.Releases
+	}
+
+	d.SetId(applicationId(d))
+	d.Set("releases", flattenReleases(releases))
+
+	return nil
+}
+
+func flattenReleases(releases []*gen.Release) []interface{} {
+	rs := make([]interface{}, len(releases))
+
+	for r, release := range releases {
+		rel := make(map[string]interface{})
+
+		rel["id"] = release.Id
+		rel["sequence"] = int(release.Sequence)
+		rel["status"] = release.GetStatus().GetState().String()
+		rel["physical_state"] = release.State.String()
+		rel["component"] = release.GetComponent().GetName()
+		rel["deployment_id"] = release.DeploymentId
+		rel["url"] = release.Url
+		rel["labels"] = release.Labels
+		rel["job_id"] = release.JobId
+		rs[r] = rel
+	}
+	return rs
+}
@@ -0,0 +1,67 @@
+package waypoint
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceWaypointReleasesLatest(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceReleasesLatest(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_releases.test", "releases.#", "1"),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_releases.test", "releases.0.id", regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_releases.test", "releases.0.sequence", regexp.MustCompile("^[1-9][0-9]*$")),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_releases.test", "releases.0.status", "SUCCESS"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWaypointReleasesSequence(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceReleasesSequence(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"data.waypoint_releases.test", "releases.#", "1"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_releases.test", "releases.0.sequence", "1"),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceReleasesLatest() string {
+	return `
+data "waypoint_releases" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  latest           = true
+}
+`
+}
+
+func testAccDataSourceReleasesSequence() string {
+	return `
+data "waypoint_releases" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  sequence         = 1
+}
+`
+}
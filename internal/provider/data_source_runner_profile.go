@@ -46,15 +46,44 @@ func dataSourceRunnerProfile() *schema.Resource {
 				Computed:    true,
 				Description: "Indicates if this runner profile is the default for any new projects",
 			},
+			"target_runner": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "The runners that may launch on demand runners for this profile.",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "The ID of the target runner.",
+						},
+						"labels": {
+							Type:        schema.TypeMap,
+							Computed:    true,
+							Description: "A map of labels on target runners.",
+							Elem: &schema.Schema{
+								Type: schema.TypeString,
+							},
+						},
+						"any": {
+							Type:        schema.TypeBool,
+							Computed:    true,
+							Description: "Whether any runner is targeted.",
+						},
+					},
+				},
+			},
 			"target_runner_id": {
 				Type:        schema.TypeString,
 				Computed:    true,
 				Description: "The ID of the target runner for this profile.",
+				Deprecated:  "Use target_runner.0.id instead.",
 			},
 			"target_runner_labels": {
 				Type:        schema.TypeMap,
 				Computed:    true,
 				Description: "A map of labels on target runners",
+				Deprecated:  "Use target_runner.0.labels instead.",
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -76,6 +105,14 @@ func dataSourceRunnerProfileRead(ctx context.Context, d *schema.ResourceData, m
 	var diags diag.Diagnostics
 
 	resourceRunnerProfileRead(context.TODO(), d, m)
+	setFlatTargetRunner(d)
 
 	return diags
 }
+
+// setFlatTargetRunner sets the deprecated target_runner_id and
+// target_runner_labels from the target_runner block.
+func setFlatTargetRunner(d *schema.ResourceData) {
+	d.Set("target_runner_id", d.Get("target_runner.0.id"))
+	d.Set("target_runner_labels", d.Get("target_runner.0.labels"))
+}
@@ -12,8 +12,8 @@ func TestAccDataSourceRunnerProfileId(t *testing.T) {
 	rName := sdkacctest.RandomWithPrefix(providerName)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceRunnerProfileId(rName),
@@ -28,6 +28,8 @@ func TestAccDataSourceRunnerProfileId(t *testing.T) {
 						"data.waypoint_runner_profile.target_id", "default", regexp.MustCompile("true")),
 					resource.TestMatchResourceAttr(
 						"data.waypoint_runner_profile.target_id", "target_runner_id", regexp.MustCompile("01G5GNJEYC7RVJNXFGMHD0HCDT")),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_runner_profile.target_id", "target_runner.0.id", regexp.MustCompile("01G5GNJEYC7RVJNXFGMHD0HCDT")),
 					resource.TestMatchResourceAttr(
 						"data.waypoint_runner_profile.target_id", "environment_variables.VAULT_ADDR", regexp.MustCompile("https://localhost:8200")),
 				),
@@ -39,11 +41,14 @@ func TestAccDataSourceRunnerProfileId(t *testing.T) {
 func testAccDataSourceRunnerProfileId(name string) string {
 	return fmt.Sprintf(`
 resource "waypoint_runner_profile" "target_id" {
-  profile_name     = "%s"
-  oci_url          = "hashicorp/waypoint-odr:latest"
-  plugin_type      = "docker"
-  default          = true
-  target_runner_id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
 
   environment_variables = {
     VAULT_ADDR           = "https://localhost:8200"
@@ -64,8 +69,8 @@ func TestAccDataSourceRunnerProfileLabels(t *testing.T) {
 	rName := sdkacctest.RandomWithPrefix(providerName)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccDataSourceRunnerProfileLabels(rName),
@@ -80,6 +85,8 @@ func TestAccDataSourceRunnerProfileLabels(t *testing.T) {
 						"data.waypoint_runner_profile.target_id", "default", regexp.MustCompile("true")),
 					resource.TestMatchResourceAttr(
 						"data.waypoint_runner_profile.target_id", "target_runner_labels.app", regexp.MustCompile("payments")),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_runner_profile.target_id", "target_runner.0.labels.app", regexp.MustCompile("payments")),
 					resource.TestMatchResourceAttr(
 						"data.waypoint_runner_profile.target_id", "environment_variables.VAULT_ADDR", regexp.MustCompile("https://localhost:8200")),
 				),
@@ -91,12 +98,15 @@ func TestAccDataSourceRunnerProfileLabels(t *testing.T) {
 func testAccDataSourceRunnerProfileLabels(name string) string {
 	return fmt.Sprintf(`
 resource "waypoint_runner_profile" "target_id" {
-  profile_name     = "%s"
-  oci_url          = "hashicorp/waypoint-odr:latest"
-  plugin_type      = "docker"
-  default          = true
-  target_runner_labels = {
-    app = "payments"
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    labels = {
+      app = "payments"
+    }
   }
 
   environment_variables = {
@@ -0,0 +1,60 @@
+package waypoint
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func dataSourceServerInfo() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceServerInfoRead,
+		Description: "A data source to read the version and supported protocol ranges of the Waypoint server",
+		Schema: map[string]*schema.Schema{
+			"version": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Full version of the Waypoint server. This may be blank if the server hides its version.",
+			},
+			"api_protocol_current": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current API protocol version supported by the server",
+			},
+			"api_protocol_minimum": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum API protocol version supported by the server",
+			},
+			"entrypoint_protocol_current": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Current entrypoint protocol version supported by the server",
+			},
+			"entrypoint_protocol_minimum": {
+				Type:        schema.TypeInt,
+				Computed:    true,
+				Description: "Minimum entrypoint protocol version supported by the server",
+			},
+		},
+	}
+}
+
+func dataSourceServerInfoRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	info, err := wp.GetVersionInfo(ctx)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId("waypoint-server")
+	d.Set("version", info.Version)
+	d.Set("api_protocol_current", info.GetApi().GetCurrent())
+	d.Set("api_protocol_minimum", info.GetApi().GetMinimum())
+	d.Set("entrypoint_protocol_current", info.GetEntrypoint().GetCurrent())
+	d.Set("entrypoint_protocol_minimum", info.GetEntrypoint().GetMinimum())
+
+	return nil
+}
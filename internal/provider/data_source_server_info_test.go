@@ -0,0 +1,33 @@
+package waypoint
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceWaypointServerInfo(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceServerInfo(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.waypoint_server_info.test", "version", regexp.MustCompile(`^v?\d+\.\d+\.\d+`)),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_server_info.test", "api_protocol_current", regexp.MustCompile(`^\d+$`)),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_server_info.test", "entrypoint_protocol_current", regexp.MustCompile(`^\d+$`)),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceServerInfo() string {
+	return `
+data "waypoint_server_info" "test" {}
+`
+}
@@ -0,0 +1,196 @@
+package waypoint
+
+import (
+	"context"
+	"time"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+func dataSourceStatusReport() *schema.Resource {
+	return &schema.Resource{
+		ReadContext: dataSourceStatusReportRead,
+		Description: "A data source to read the latest status report of a Waypoint deployment or release",
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the Waypoint project",
+			},
+			"application_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				Description: "The name of the application in the Waypoint project",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				Description: "The workspace to read the status report from",
+			},
+			"target": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				ValidateFunc:  validation.StringInSlice([]string{"any", "deployment", "release"}, false),
+				ConflictsWith: []string{"deployment_id", "release_id"},
+				Description:   "Read the latest report of `any` target, of any `deployment` or of any `release`. Defaults to `any` when neither `deployment_id` nor `release_id` is set.",
+			},
+			"deployment_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"release_id"},
+				Description:   "Read the latest report of this deployment. Set to the reported deployment otherwise.",
+			},
+			"release_id": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Computed:      true,
+				ConflictsWith: []string{"deployment_id"},
+				Description:   "Read the latest report of this release. Set to the reported release otherwise.",
+			},
+			"status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Status of the operation generating the report, one of `RUNNING`, `SUCCESS`, `ERROR` or `UNKNOWN`",
+			},
+			"health_status": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Overall health of the target, such as `READY`, `ALIVE`, `DOWN`, `PARTIAL`, `MISSING` or `UNKNOWN`",
+			},
+			"health_message": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Message explaining the overall health of the target",
+			},
+			"generated_time": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "RFC 3339 timestamp of when the report was generated",
+			},
+			"external": {
+				Type:        schema.TypeBool,
+				Computed:    true,
+				Description: "Whether the report was generated outside of Waypoint",
+			},
+			"resources": {
+				Type:        schema.TypeList,
+				Computed:    true,
+				Description: "Health of each resource covered by the report",
+				Elem: &schema.Resource{
+					Schema: map[string]*schema.Schema{
+						"id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the resource",
+						},
+						"parent_resource_id": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "ID of the resource that created this resource",
+						},
+						"name": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Name of the resource",
+						},
+						"type": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Platform specific type of the resource, such as `pod`",
+						},
+						"platform": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Platform the resource runs on, such as `kubernetes`",
+						},
+						"platform_url": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "URL to the resource in the platform's console",
+						},
+						"health": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Health of the resource, such as `READY`, `ALIVE`, `DOWN`, `PARTIAL`, `MISSING` or `UNKNOWN`",
+						},
+						"health_message": {
+							Type:        schema.TypeString,
+							Computed:    true,
+							Description: "Message explaining the health of the resource",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func dataSourceStatusReportRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	glsr := &gen.GetLatestStatusReportRequest{
+		Application: applicationRef(d),
+		Workspace:   workspaceRef(d),
+	}
+
+	deploymentId := d.Get("deployment_id").(string)
+	releaseId := d.Get("release_id").(string)
+
+	switch {
+	case deploymentId != "":
+		glsr.Target = &gen.GetLatestStatusReportRequest_DeploymentId{DeploymentId: deploymentId}
+	case releaseId != "":
+		glsr.Target = &gen.GetLatestStatusReportRequest_ReleaseId{ReleaseId: releaseId}
+	case d.Get("target").(string) == "deployment":
+		glsr.Target = &gen.GetLatestStatusReportRequest_DeploymentAny{DeploymentAny: &emptypb.Empty{}}
+	case d.Get("target").(string) == "release":
+		glsr.Target = &gen.GetLatestStatusReportRequest_ReleaseAny{ReleaseAny: &emptypb.Empty{}}
+	default:
+		glsr.Target = &gen.GetLatestStatusReportRequest_Any{Any: &emptypb.Empty{}}
+	}
+
+	report, err := wp.GRPCClient().GetLatestStatusReport(ctx, glsr)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(report.Id)
+	d.Set("deployment_id", report.GetDeploymentId())
+	d.Set("release_id", report.GetReleaseId())
+	d.Set("status", report.GetStatus().GetState().String())
+	d.Set("health_status", report.GetHealth().GetHealthStatus())
+	d.Set("health_message", report.GetHealth().GetHealthMessage())
+	d.Set("external", report.External)
+	d.Set("resources", flattenStatusReportResources(report.Resources))
+
+	if report.GeneratedTime != nil {
+		d.Set("generated_time", report.GeneratedTime.AsTime().Format(time.RFC3339))
+	}
+
+	return nil
+}
+
+func flattenStatusReportResources(resources []*gen.StatusReport_Resource) []interface{} {
+	rs := make([]interface{}, len(resources))
+
+	for r, resource := range resources {
+		res := make(map[string]interface{})
+
+		res["id"] = resource.Id
+		res["parent_resource_id"] = resource.ParentResourceId
+		res["name"] = resource.Name
+		res["type"] = resource.Type
+		res["platform"] = resource.Platform
+		res["platform_url"] = resource.PlatformUrl
+		res["health"] = resource.Health.String()
+		res["health_message"] = resource.HealthMessage
+		rs[r] = res
+	}
+	return rs
+}
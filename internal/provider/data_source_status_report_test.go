@@ -0,0 +1,76 @@
+package waypoint
+
+import (
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"regexp"
+	"testing"
+)
+
+func TestAccDataSourceWaypointStatusReportDeployment(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStatusReportDeployment(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.waypoint_status_report.test", "id", regexp.MustCompile(".+")),
+					resource.TestCheckResourceAttrPair(
+						"data.waypoint_status_report.test", "deployment_id",
+						"data.waypoint_deployments.test", "deployments.0.id"),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_status_report.test", "health_status", "READY"),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_status_report.test", "resources.#", regexp.MustCompile("^[1-9][0-9]*$")),
+				),
+			},
+		},
+	})
+}
+
+func TestAccDataSourceWaypointStatusReportRelease(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccDataSourceStatusReportRelease(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestMatchResourceAttr(
+						"data.waypoint_status_report.test", "release_id", regexp.MustCompile(".+")),
+					resource.TestCheckResourceAttr(
+						"data.waypoint_status_report.test", "deployment_id", ""),
+					resource.TestMatchResourceAttr(
+						"data.waypoint_status_report.test", "health_status", regexp.MustCompile("^[A-Z]+$")),
+				),
+			},
+		},
+	})
+}
+
+func testAccDataSourceStatusReportDeployment() string {
+	return `
+data "waypoint_deployments" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  latest           = true
+}
+
+data "waypoint_status_report" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  deployment_id    = data.waypoint_deployments.test.deployments[0].id
+}
+`
+}
+
+func testAccDataSourceStatusReportRelease() string {
+	return `
+data "waypoint_status_report" "test" {
+  project_name     = "example"
+  application_name = "example-nodejs"
+  target           = "release"
+}
+`
+}
@@ -0,0 +1,116 @@
+package waypoint
+
+import (
+	"bytes"
+	"encoding/json"
+	"reflect"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+// suppressEquivalentConfig returns a DiffSuppressFunc for a string attribute
+// holding HCL or JSON, as chosen by the gen.Hcl_Format in formatKey.
+func suppressEquivalentConfig(formatKey string) schema.SchemaDiffSuppressFunc {
+	return func(k, old, new string, d *schema.ResourceData) bool {
+		if format, ok := d.Get(formatKey).(int); ok && gen.Hcl_Format(format) == gen.Hcl_JSON {
+			return equivalentJson(old, new)
+		}
+
+		return equivalentHcl(old, new)
+	}
+}
+
+// equivalentJson reports whether a and b are the same JSON document,
+// regardless of whitespace and key order.
+func equivalentJson(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	var aValue, bValue interface{}
+
+	if err := json.Unmarshal([]byte(a), &aValue); err != nil {
+		return false
+	}
+
+	if err := json.Unmarshal([]byte(b), &bValue); err != nil {
+		return false
+	}
+
+	return reflect.DeepEqual(aValue, bValue)
+}
+
+// equivalentHcl reports whether a and b are the same HCL configuration,
+// regardless of formatting, comments and the order of attributes. Blocks
+// must appear in the same order, as it is significant to most plugins.
+func equivalentHcl(a, b string) bool {
+	if a == b {
+		return true
+	}
+
+	aFile, diags := hclsyntax.ParseConfig([]byte(a), "a", hcl.InitialPos)
+	if diags.HasErrors() {
+		return false
+	}
+
+	bFile, diags := hclsyntax.ParseConfig([]byte(b), "b", hcl.InitialPos)
+	if diags.HasErrors() {
+		return false
+	}
+
+	return equivalentHclBody(aFile.Body.(*hclsyntax.Body), aFile.Bytes, bFile.Body.(*hclsyntax.Body), bFile.Bytes)
+}
+
+func equivalentHclBody(a *hclsyntax.Body, aSrc []byte, b *hclsyntax.Body, bSrc []byte) bool {
+	if len(a.Attributes) != len(b.Attributes) || len(a.Blocks) != len(b.Blocks) {
+		return false
+	}
+
+	for name, aAttribute := range a.Attributes {
+		bAttribute, ok := b.Attributes[name]
+		if !ok {
+			return false
+		}
+
+		if !equivalentHclExpression(aAttribute.Expr, aSrc, bAttribute.Expr, bSrc) {
+			return false
+		}
+	}
+
+	for i, aBlock := range a.Blocks {
+		bBlock := b.Blocks[i]
+
+		if aBlock.Type != bBlock.Type || !reflect.DeepEqual(aBlock.Labels, bBlock.Labels) {
+			return false
+		}
+
+		if !equivalentHclBody(aBlock.Body, aSrc, bBlock.Body, bSrc) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// equivalentHclExpression compares the values of two expressions. Those
+// that cannot be evaluated without a context, such as references, are
+// compared by their formatted source instead.
+func equivalentHclExpression(a hclsyntax.Expression, aSrc []byte, b hclsyntax.Expression, bSrc []byte) bool {
+	aValue, aDiags := a.Value(nil)
+	bValue, bDiags := b.Value(nil)
+
+	if !aDiags.HasErrors() && !bDiags.HasErrors() {
+		return aValue.IsWhollyKnown() && bValue.IsWhollyKnown() && aValue.Equals(bValue).True()
+	}
+
+	aRange, bRange := a.Range(), b.Range()
+
+	return bytes.Equal(
+		hclwrite.Format(aRange.SliceBytes(aSrc)),
+		hclwrite.Format(bRange.SliceBytes(bSrc)),
+	)
+}
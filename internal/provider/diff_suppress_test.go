@@ -0,0 +1,50 @@
+package waypoint
+
+import (
+	"testing"
+)
+
+func TestEquivalentHcl(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{"namespace = \"waypoint\"\n", "namespace=\"waypoint\"", true},
+		{"a = 1\nb = \"x\"\n", "b = \"x\"\n\n# comment\na = 1\n", true},
+		{"cpu {\n  request = \"250m\"\n  limit = \"500m\"\n}\n", "cpu {\n  limit   = \"500m\"\n  request = \"250m\"\n}", true},
+		{"binds = [\"/a:/a\", \"/b:/b\"]\n", "binds = [\n  \"/a:/a\",\n  \"/b:/b\",\n]\n", true},
+		{"labels = { a = \"b\", c = \"d\" }\n", "labels = {\n  c = \"d\"\n  a = \"b\"\n}\n", true},
+		{"path = var.path\n", "path =   var.path", true},
+		{"a = 1\n", "a = 2\n", false},
+		{"a = 1\n", "a = 1\nb = 2\n", false},
+		{"binds = [\"/a:/a\", \"/b:/b\"]\n", "binds = [\"/b:/b\", \"/a:/a\"]\n", false},
+		{"cpu {}\nmemory {}\n", "memory {}\ncpu {}\n", false},
+		{"a = 1\n", "a = \n", false},
+		{"", "a = 1\n", false},
+	}
+
+	for _, tc := range cases {
+		if got := equivalentHcl(tc.a, tc.b); got != tc.want {
+			t.Errorf("equivalentHcl(%q, %q) = %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
+
+func TestEquivalentJson(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want bool
+	}{
+		{`{"a": 1, "b": [true]}`, `{"b":[true],"a":1}`, true},
+		{`{"a": 1.0}`, `{"a": 1}`, true},
+		{`{"a": 1}`, `{"a": "1"}`, false},
+		{`{"a": [1, 2]}`, `{"a": [2, 1]}`, false},
+		{`{"a": 1}`, `{"a": 1`, false},
+	}
+
+	for _, tc := range cases {
+		if got := equivalentJson(tc.a, tc.b); got != tc.want {
+			t.Errorf("equivalentJson(%q, %q) = %t, want %t", tc.a, tc.b, got, tc.want)
+		}
+	}
+}
@@ -0,0 +1,209 @@
+package waypoint
+
+import (
+	"context"
+	"strings"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// parseWaypointHclFunction lists the applications declared in a waypoint.hcl
+// file along with the plugins used by each of their operations.
+type parseWaypointHclFunction struct{}
+
+type waypointHclAppModel struct {
+	Name     types.String `tfsdk:"name"`
+	Builder  types.String `tfsdk:"builder"`
+	Registry types.String `tfsdk:"registry"`
+	Platform types.String `tfsdk:"platform"`
+	Releaser types.String `tfsdk:"releaser"`
+}
+
+var waypointHclAppType = types.ObjectType{
+	AttrTypes: map[string]attr.Type{
+		"name":     types.StringType,
+		"builder":  types.StringType,
+		"registry": types.StringType,
+		"platform": types.StringType,
+		"releaser": types.StringType,
+	},
+}
+
+var _ function.Function = &parseWaypointHclFunction{}
+
+func newParseWaypointHclFunction() function.Function {
+	return &parseWaypointHclFunction{}
+}
+
+func (f *parseWaypointHclFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "parse_waypoint_hcl"
+}
+
+func (f *parseWaypointHclFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "List the applications declared in a waypoint.hcl file",
+		Description: "Parses a waypoint.hcl file, in HCL or JSON syntax, and returns a list of its applications " +
+			"with the `use` plugin of their `build`, `registry`, `deploy` and `release` stanzas. " +
+			"Plugins that are not configured are returned as `null`.",
+
+		Parameters: []function.Parameter{
+			function.StringParameter{
+				Name:        "waypoint_hcl",
+				Description: "Contents of the waypoint.hcl file",
+			},
+		},
+		Return: function.ListReturn{
+			ElementType: waypointHclAppType,
+		},
+	}
+}
+
+func (f *parseWaypointHclFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var waypointHcl string
+
+	resp.Error = req.Arguments.Get(ctx, &waypointHcl)
+	if resp.Error != nil {
+		return
+	}
+
+	apps, diags := parseWaypointHclApps(waypointHcl)
+	if diags.HasErrors() {
+		resp.Error = function.NewArgumentFuncError(0, diags.Error())
+		return
+	}
+
+	result, d := types.ListValueFrom(ctx, waypointHclAppType, apps)
+	resp.Error = function.FuncErrorFromDiags(ctx, d)
+	if resp.Error != nil {
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, result)
+}
+
+var (
+	waypointHclSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "app", LabelNames: []string{"name"}},
+		},
+	}
+
+	waypointHclAppSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "build"},
+			{Type: "deploy"},
+			{Type: "release"},
+		},
+	}
+
+	waypointHclBuildSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "use", LabelNames: []string{"plugin"}},
+			{Type: "registry"},
+		},
+	}
+
+	waypointHclUseSchema = &hcl.BodySchema{
+		Blocks: []hcl.BlockHeaderSchema{
+			{Type: "use", LabelNames: []string{"plugin"}},
+		},
+	}
+)
+
+// parseWaypointHcl parses the contents of a waypoint.hcl file in the given
+// syntax.
+func parseWaypointHcl(src string, format gen.Hcl_Format) (*hcl.File, hcl.Diagnostics) {
+	if format == gen.Hcl_JSON {
+		return hcljson.Parse([]byte(src), "waypoint.hcl.json")
+	}
+
+	return hclsyntax.ParseConfig([]byte(src), "waypoint.hcl", hcl.InitialPos)
+}
+
+// detectWaypointHclFormat returns the syntax of the contents of a
+// waypoint.hcl file. Contents starting with "{" are JSON, everything else
+// native HCL.
+func detectWaypointHclFormat(src string) gen.Hcl_Format {
+	if strings.HasPrefix(strings.TrimSpace(src), "{") {
+		return gen.Hcl_JSON
+	}
+
+	return gen.Hcl_HCL
+}
+
+// parseWaypointHclApps returns the applications declared in the contents of
+// a waypoint.hcl file, in the order they are declared.
+func parseWaypointHclApps(src string) ([]waypointHclAppModel, hcl.Diagnostics) {
+	file, diags := parseWaypointHcl(src, detectWaypointHclFormat(src))
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	content, _, diags := file.Body.PartialContent(waypointHclSchema)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	apps := make([]waypointHclAppModel, 0, len(content.Blocks))
+
+	for _, block := range content.Blocks {
+		app := waypointHclAppModel{
+			Name:     types.StringValue(block.Labels[0]),
+			Builder:  types.StringNull(),
+			Registry: types.StringNull(),
+			Platform: types.StringNull(),
+			Releaser: types.StringNull(),
+		}
+
+		appContent, _, d := block.Body.PartialContent(waypointHclAppSchema)
+		diags = append(diags, d...)
+
+		for _, stanza := range appContent.Blocks {
+			switch stanza.Type {
+			case "build":
+				buildContent, _, d := stanza.Body.PartialContent(waypointHclBuildSchema)
+				diags = append(diags, d...)
+
+				for _, buildBlock := range buildContent.Blocks {
+					switch buildBlock.Type {
+					case "use":
+						app.Builder = types.StringValue(buildBlock.Labels[0])
+					case "registry":
+						app.Registry = waypointHclPlugin(buildBlock.Body, &diags)
+					}
+				}
+			case "deploy":
+				app.Platform = waypointHclPlugin(stanza.Body, &diags)
+			case "release":
+				app.Releaser = waypointHclPlugin(stanza.Body, &diags)
+			}
+		}
+
+		apps = append(apps, app)
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return apps, nil
+}
+
+// waypointHclPlugin returns the label of the use block in body, or null if
+// there is none.
+func waypointHclPlugin(body hcl.Body, diags *hcl.Diagnostics) types.String {
+	content, _, d := body.PartialContent(waypointHclUseSchema)
+	*diags = append(*diags, d...)
+
+	if len(content.Blocks) == 0 {
+		return types.StringNull()
+	}
+
+	return types.StringValue(content.Blocks[0].Labels[0])
+}
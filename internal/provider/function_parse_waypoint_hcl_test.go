@@ -0,0 +1,138 @@
+package waypoint
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWaypointParseWaypointHclFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccParseWaypointHclFunction(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("app_count", "2"),
+					resource.TestCheckOutput("web_builder", "docker"),
+					resource.TestCheckOutput("web_registry", "aws-ecr"),
+					resource.TestCheckOutput("web_platform", "kubernetes"),
+					resource.TestCheckOutput("api_builder", "pack"),
+					resource.TestCheckOutput("api_has_platform", "false"),
+				),
+			},
+		},
+	})
+}
+
+func TestParseWaypointHclApps(t *testing.T) {
+	null := types.StringNull()
+	str := types.StringValue
+
+	cases := []struct {
+		name      string
+		src       string
+		want      []waypointHclAppModel
+		wantError bool
+	}{
+		{
+			"all stanzas",
+			"app \"web\" {\n  build {\n    use \"docker\" {}\n    registry {\n      use \"aws-ecr\" {}\n    }\n  }\n  deploy {\n    use \"kubernetes\" {}\n  }\n  release {\n    use \"kubernetes\" {}\n  }\n}\n",
+			[]waypointHclAppModel{{str("web"), str("docker"), str("aws-ecr"), str("kubernetes"), str("kubernetes")}},
+			false,
+		},
+		{
+			"unconfigured plugins",
+			"project = \"example\"\n\napp \"web\" {\n  build {\n    use \"pack\" {}\n  }\n}\n\napp \"api\" {}\n",
+			[]waypointHclAppModel{{str("web"), str("pack"), null, null, null}, {str("api"), null, null, null, null}},
+			false,
+		},
+		{
+			"json",
+			`{"app": {"web": {"build": {"use": {"docker": {}}}, "deploy": {"use": {"nomad": {}}}}}}`,
+			[]waypointHclAppModel{{str("web"), str("docker"), null, str("nomad"), null}},
+			false,
+		},
+		{"no apps", "project = \"example\"\n", []waypointHclAppModel{}, false},
+		{"malformed", "app \"web\" {\n", nil, true},
+		{"missing label", "app {}\n", nil, true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			apps, diags := parseWaypointHclApps(tc.src)
+
+			if diags.HasErrors() != tc.wantError {
+				t.Fatalf("got error %t, want %t: %v", diags.HasErrors(), tc.wantError, diags)
+			}
+
+			if len(apps) != len(tc.want) {
+				t.Fatalf("got %d apps, want %d", len(apps), len(tc.want))
+			}
+
+			for i, app := range apps {
+				if app != tc.want[i] {
+					t.Errorf("app %d: got %v, want %v", i, app, tc.want[i])
+				}
+			}
+		})
+	}
+}
+
+func testAccParseWaypointHclFunction() string {
+	return `
+locals {
+  apps = provider::waypoint::parse_waypoint_hcl(<<EOF
+project = "example"
+
+app "web" {
+  build {
+    use "docker" {}
+
+    registry {
+      use "aws-ecr" {
+        region = "eu-west-2"
+      }
+    }
+  }
+
+  deploy {
+    use "kubernetes" {}
+  }
+}
+
+app "api" {
+  build {
+    use "pack" {}
+  }
+}
+EOF
+  )
+}
+
+output "app_count" {
+  value = length(local.apps)
+}
+
+output "web_builder" {
+  value = local.apps[0].builder
+}
+
+output "web_registry" {
+  value = local.apps[0].registry
+}
+
+output "web_platform" {
+  value = local.apps[0].platform
+}
+
+output "api_builder" {
+  value = local.apps[1].builder
+}
+
+output "api_has_platform" {
+  value = local.apps[1].platform != null
+}`
+}
@@ -0,0 +1,196 @@
+package waypoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/zclconf/go-cty/cty"
+)
+
+// runnerProfileConfigFunction renders the plugin_config of a runner profile
+// from a Terraform object, so the HCL is always well formed.
+type runnerProfileConfigFunction struct{}
+
+var _ function.Function = &runnerProfileConfigFunction{}
+
+func newRunnerProfileConfigFunction() function.Function {
+	return &runnerProfileConfigFunction{}
+}
+
+func (f *runnerProfileConfigFunction) Metadata(ctx context.Context, req function.MetadataRequest, resp *function.MetadataResponse) {
+	resp.Name = "runner_profile_config"
+}
+
+func (f *runnerProfileConfigFunction) Definition(ctx context.Context, req function.DefinitionRequest, resp *function.DefinitionResponse) {
+	resp.Definition = function.Definition{
+		Summary: "Render runner profile plugin configuration as HCL",
+		Description: "Renders an object as HCL for the `plugin_config` argument of `waypoint_runner_profile`. " +
+			"Attributes set to `null` are left out, nested objects are rendered as blocks, " +
+			"and maps, such as those built with `tomap()`, are rendered as attributes.",
+
+		Parameters: []function.Parameter{
+			function.DynamicParameter{
+				Name:        "config",
+				Description: "Object of plugin configuration, such as `{ namespace = \"waypoint\" }`",
+			},
+		},
+		Return: function.StringReturn{},
+	}
+}
+
+func (f *runnerProfileConfigFunction) Run(ctx context.Context, req function.RunRequest, resp *function.RunResponse) {
+	var config types.Dynamic
+
+	resp.Error = req.Arguments.Get(ctx, &config)
+	if resp.Error != nil {
+		return
+	}
+
+	file := hclwrite.NewEmptyFile()
+
+	if err := writeHclBody(file.Body(), config.UnderlyingValue()); err != nil {
+		resp.Error = function.NewArgumentFuncError(0, err.Error())
+		return
+	}
+
+	resp.Error = resp.Result.Set(ctx, string(file.Bytes()))
+}
+
+// writeHclBody writes the attributes of an object or map value into body.
+// Nested objects are written as blocks and every other value as an
+// attribute.
+func writeHclBody(body *hclwrite.Body, value attr.Value) error {
+	var attributes map[string]attr.Value
+
+	switch v := value.(type) {
+	case basetypes.ObjectValue:
+		attributes = v.Attributes()
+	case basetypes.MapValue:
+		attributes = v.Elements()
+	default:
+		return fmt.Errorf("expected an object, got %s", value.Type(context.Background()))
+	}
+
+	names := make([]string, 0, len(attributes))
+	for name := range attributes {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	// Attributes are written before blocks, as hclfmt would order them.
+	var blocks []string
+
+	for _, name := range names {
+		attribute := attributes[name]
+		if dynamic, ok := attribute.(basetypes.DynamicValue); ok {
+			attribute = dynamic.UnderlyingValue()
+		}
+
+		if attribute == nil || attribute.IsNull() {
+			continue
+		}
+
+		if !hclsyntax.ValidIdentifier(name) {
+			return fmt.Errorf("%q is not a valid HCL attribute name", name)
+		}
+
+		if _, ok := attribute.(basetypes.ObjectValue); ok {
+			blocks = append(blocks, name)
+			continue
+		}
+
+		ctyValue, err := ctyValueOf(attribute)
+		if err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+
+		body.SetAttributeValue(name, ctyValue)
+	}
+
+	for _, name := range blocks {
+		attribute := attributes[name]
+		if dynamic, ok := attribute.(basetypes.DynamicValue); ok {
+			attribute = dynamic.UnderlyingValue()
+		}
+
+		if err := writeHclBody(body.AppendNewBlock(name, nil).Body(), attribute); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	return nil
+}
+
+// ctyValueOf converts a framework value into the cty value hclwrite needs to
+// render it.
+func ctyValueOf(value attr.Value) (cty.Value, error) {
+	if value == nil || value.IsNull() {
+		return cty.NullVal(cty.DynamicPseudoType), nil
+	}
+
+	if value.IsUnknown() {
+		return cty.NilVal, fmt.Errorf("value is not yet known")
+	}
+
+	switch v := value.(type) {
+	case basetypes.DynamicValue:
+		return ctyValueOf(v.UnderlyingValue())
+	case basetypes.StringValue:
+		return cty.StringVal(v.ValueString()), nil
+	case basetypes.BoolValue:
+		return cty.BoolVal(v.ValueBool()), nil
+	case basetypes.NumberValue:
+		return cty.NumberVal(v.ValueBigFloat()), nil
+	case basetypes.Int64Value:
+		return cty.NumberIntVal(v.ValueInt64()), nil
+	case basetypes.Float64Value:
+		return cty.NumberFloatVal(v.ValueFloat64()), nil
+	case basetypes.ListValue:
+		return ctyTupleOf(v.Elements())
+	case basetypes.SetValue:
+		return ctyTupleOf(v.Elements())
+	case basetypes.TupleValue:
+		return ctyTupleOf(v.Elements())
+	case basetypes.MapValue:
+		return ctyObjectOf(v.Elements())
+	case basetypes.ObjectValue:
+		return ctyObjectOf(v.Attributes())
+	}
+
+	return cty.NilVal, fmt.Errorf("unsupported value type %s", value.Type(context.Background()))
+}
+
+func ctyTupleOf(elements []attr.Value) (cty.Value, error) {
+	values := make([]cty.Value, len(elements))
+
+	for i, element := range elements {
+		value, err := ctyValueOf(element)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		values[i] = value
+	}
+
+	return cty.TupleVal(values), nil
+}
+
+func ctyObjectOf(attributes map[string]attr.Value) (cty.Value, error) {
+	values := make(map[string]cty.Value, len(attributes))
+
+	for name, attribute := range attributes {
+		value, err := ctyValueOf(attribute)
+		if err != nil {
+			return cty.NilVal, err
+		}
+		values[name] = value
+	}
+
+	return cty.ObjectVal(values), nil
+}
@@ -0,0 +1,154 @@
+package waypoint
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWaypointRunnerProfileConfigFunction(t *testing.T) {
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunnerProfileConfigFunction(),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckOutput("plugin_config", `service_account = "waypoint-runner"
+static_environment = {
+  LOG_LEVEL = "debug"
+}
+cpu {
+  limit   = "500m"
+  request = "250m"
+}
+`),
+				),
+			},
+		},
+	})
+}
+
+func TestWriteHclBody(t *testing.T) {
+	object := func(attributes map[string]attr.Value) attr.Value {
+		attrTypes := map[string]attr.Type{}
+		for name, value := range attributes {
+			attrTypes[name] = value.Type(context.Background())
+		}
+		return types.ObjectValueMust(attrTypes, attributes)
+	}
+
+	cases := []struct {
+		name      string
+		value     attr.Value
+		want      string
+		wantError string
+	}{
+		{
+			"attributes sorted",
+			object(map[string]attr.Value{
+				"namespace": types.StringValue("waypoint"),
+				"cpu":       types.Int64Value(500),
+				"enabled":   types.BoolValue(true),
+			}),
+			"cpu       = 500\nenabled   = true\nnamespace = \"waypoint\"\n",
+			"",
+		},
+		{
+			"null left out",
+			object(map[string]attr.Value{
+				"namespace": types.StringValue("waypoint"),
+				"region":    types.StringNull(),
+			}),
+			"namespace = \"waypoint\"\n",
+			"",
+		},
+		{
+			"nested object as block after attributes",
+			object(map[string]attr.Value{
+				"auth":  object(map[string]attr.Value{"token": types.StringValue("secret")}),
+				"image": types.StringValue("busybox"),
+			}),
+			"image = \"busybox\"\nauth {\n  token = \"secret\"\n}\n",
+			"",
+		},
+		{
+			"map as attribute",
+			object(map[string]attr.Value{
+				"labels": types.MapValueMust(types.StringType, map[string]attr.Value{"team": types.StringValue("platform")}),
+			}),
+			"labels = {\n  team = \"platform\"\n}\n",
+			"",
+		},
+		{
+			"top-level map",
+			types.MapValueMust(types.StringType, map[string]attr.Value{"region": types.StringValue("eu-west-2")}),
+			"region = \"eu-west-2\"\n",
+			"",
+		},
+		{
+			"dynamic",
+			object(map[string]attr.Value{
+				"count": types.DynamicValue(types.Int64Value(2)),
+			}),
+			"count = 2\n",
+			"",
+		},
+		{"not an object", types.StringValue("waypoint"), "", "expected an object"},
+		{
+			"invalid name",
+			types.MapValueMust(types.StringType, map[string]attr.Value{"not valid": types.StringValue("x")}),
+			"",
+			`"not valid" is not a valid HCL attribute name`,
+		},
+		{
+			"unknown",
+			object(map[string]attr.Value{"namespace": types.StringUnknown()}),
+			"",
+			"namespace: value is not yet known",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			file := hclwrite.NewEmptyFile()
+			err := writeHclBody(file.Body(), tc.value)
+
+			if tc.wantError != "" {
+				if err == nil || !strings.Contains(err.Error(), tc.wantError) {
+					t.Fatalf("got error %v, want %q", err, tc.wantError)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got := string(file.Bytes()); got != tc.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func testAccRunnerProfileConfigFunction() string {
+	return `
+output "plugin_config" {
+  value = provider::waypoint::runner_profile_config({
+    service_account    = "waypoint-runner"
+    image_secret       = null
+    static_environment = tomap({ LOG_LEVEL = "debug" })
+
+    cpu = {
+      request = "250m"
+      limit   = "500m"
+    }
+  })
+}`
+}
@@ -0,0 +1,114 @@
+package waypoint
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// oidcDiscoveryTimeout bounds the request made to validate an OIDC
+// discovery URL at plan time.
+const oidcDiscoveryTimeout = 10 * time.Second
+
+// oidcDiscoveryDefaultSigningAlg is the signing algorithm Waypoint uses when
+// signing_algs is not set.
+const oidcDiscoveryDefaultSigningAlg = "RS256"
+
+// oidcProviderMetadata holds the fields of the OpenID provider metadata
+// that are checked against the configuration of an auth method.
+type oidcProviderMetadata struct {
+	Issuer                           string   `json:"issuer"`
+	IDTokenSigningAlgValuesSupported []string `json:"id_token_signing_alg_values_supported"`
+	ScopesSupported                  []string `json:"scopes_supported"`
+}
+
+// validateOidcDiscovery fetches the OpenID provider metadata published under
+// discoveryURL, trusting the CA certificates in caPems when set, and checks
+// the issuer supports signingAlgs and scopes, so that a wrong discovery URL
+// or CA bundle is reported before users fail to log in.
+func validateOidcDiscovery(ctx context.Context, discoveryURL string, caPems []string, signingAlgs []string, scopes []string) error {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+
+	if len(caPems) > 0 {
+		pool := x509.NewCertPool()
+		for i, pem := range caPems {
+			if !pool.AppendCertsFromPEM([]byte(pem)) {
+				return fmt.Errorf("discovery_ca_pem.%d holds no PEM encoded certificate", i)
+			}
+		}
+
+		transport.TLSClientConfig = &tls.Config{RootCAs: pool}
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, oidcDiscoveryTimeout)
+	defer cancel()
+
+	wellKnown := strings.TrimSuffix(discoveryURL, "/") + "/.well-known/openid-configuration"
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return fmt.Errorf("invalid discovery_url: %w", err)
+	}
+
+	resp, err := (&http.Client{Transport: transport}).Do(req)
+	if err != nil {
+		return fmt.Errorf("unable to fetch %s: %w", wellKnown, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("unable to fetch %s: %s", wellKnown, resp.Status)
+	}
+
+	var metadata oidcProviderMetadata
+	if err := json.NewDecoder(resp.Body).Decode(&metadata); err != nil {
+		return fmt.Errorf("%s is not a valid OpenID provider configuration: %w", wellKnown, err)
+	}
+
+	// The issuer must be the discovery URL, or the tokens it signs are
+	// rejected.
+	if strings.TrimSuffix(metadata.Issuer, "/") != strings.TrimSuffix(discoveryURL, "/") {
+		return fmt.Errorf("the issuer %q published at %s does not match discovery_url %q", metadata.Issuer, wellKnown, discoveryURL)
+	}
+
+	if len(signingAlgs) == 0 {
+		signingAlgs = []string{oidcDiscoveryDefaultSigningAlg}
+	}
+
+	if unsupported := missingFrom(signingAlgs, metadata.IDTokenSigningAlgValuesSupported); len(unsupported) > 0 {
+		return fmt.Errorf("the issuer %q does not support the signing_algs %s, it supports %s",
+			metadata.Issuer, strings.Join(unsupported, ", "), strings.Join(metadata.IDTokenSigningAlgValuesSupported, ", "))
+	}
+
+	// scopes_supported is optional in the provider metadata.
+	if len(metadata.ScopesSupported) > 0 {
+		if unsupported := missingFrom(scopes, metadata.ScopesSupported); len(unsupported) > 0 {
+			return fmt.Errorf("the issuer %q does not support the scopes %s, it supports %s",
+				metadata.Issuer, strings.Join(unsupported, ", "), strings.Join(metadata.ScopesSupported, ", "))
+		}
+	}
+
+	return nil
+}
+
+// missingFrom returns the values that are not in supported.
+func missingFrom(values, supported []string) []string {
+	set := map[string]bool{}
+	for _, s := range supported {
+		set[s] = true
+	}
+
+	var missing []string
+	for _, v := range values {
+		if !set[v] {
+			missing = append(missing, v)
+		}
+	}
+
+	return missing
+}
@@ -0,0 +1,81 @@
+package waypoint
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"testing"
+)
+
+// newOidcDiscoveryServer starts an OIDC provider stand-in publishing its
+// configuration with issuer, and returns it with its CA certificate.
+func newOidcDiscoveryServer(t *testing.T, issuer func(url string) string) (*httptest.Server, string) {
+	var server *httptest.Server
+
+	server = httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tenant/.well-known/openid-configuration" {
+			http.NotFound(w, r)
+			return
+		}
+
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"issuer":                                issuer(server.URL + "/tenant"),
+			"authorization_endpoint":                server.URL + "/tenant/authorize",
+			"id_token_signing_alg_values_supported": []string{"RS256", "ES256"},
+			"scopes_supported":                      []string{"openid", "email", "groups"},
+		})
+	}))
+	t.Cleanup(server.Close)
+
+	caPem := string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw}))
+
+	return server, caPem
+}
+
+func TestValidateOidcDiscovery(t *testing.T) {
+	server, caPem := newOidcDiscoveryServer(t, func(url string) string { return url })
+	otherIssuer, _ := newOidcDiscoveryServer(t, func(url string) string { return "https://issuer.example.com" })
+
+	cases := []struct {
+		name         string
+		discoveryURL string
+		caPems       []string
+		signingAlgs  []string
+		scopes       []string
+		wantError    string
+	}{
+		{"valid", server.URL + "/tenant", []string{caPem}, []string{"ES256"}, []string{"email"}, ""},
+		{"trailing slash", server.URL + "/tenant/", []string{caPem}, nil, nil, ""},
+		{"default signing alg", server.URL + "/tenant", []string{caPem}, nil, []string{"groups"}, ""},
+		{"untrusted CA", server.URL + "/tenant", nil, nil, nil, "certificate"},
+		{"invalid CA", server.URL + "/tenant", []string{"cert1.crt"}, nil, nil, `discovery_ca_pem\.0 holds no PEM`},
+		{"wrong path", server.URL + "/other", []string{caPem}, nil, nil, "404 Not Found"},
+		{"issuer mismatch", otherIssuer.URL + "/tenant", []string{caPem}, nil, nil, `issuer "https://issuer.example.com".*does not match discovery_url`},
+		{"unsupported signing alg", server.URL + "/tenant", []string{caPem}, []string{"RS256", "rsa256"}, nil, "does not support the signing_algs rsa256, it supports RS256, ES256"},
+		{"unsupported scope", server.URL + "/tenant", []string{caPem}, nil, []string{"email", "profile"}, "does not support the scopes profile"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := validateOidcDiscovery(context.Background(), tc.discoveryURL, tc.caPems, tc.signingAlgs, tc.scopes)
+
+			if tc.wantError == "" {
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				return
+			}
+
+			if err == nil {
+				t.Fatalf("got no error, want %q", tc.wantError)
+			}
+
+			if !regexp.MustCompile(tc.wantError).MatchString(err.Error()) {
+				t.Errorf("got error %q, want %q", err, tc.wantError)
+			}
+		})
+	}
+}
@@ -13,6 +13,12 @@ const TerraformProviderProductUserAgent = "terraform-provider-waypoint"
 
 // Provider returns a *schema.Provider.
 func Provider() *schema.Provider {
+	return newProvider(&sharedClient{})
+}
+
+// newProvider returns a *schema.Provider getting its Waypoint client from
+// clients, which may be shared with the framework provider.
+func newProvider(clients *sharedClient) *schema.Provider {
 	provider := &schema.Provider{
 		Schema: map[string]*schema.Schema{
 			"token": {
@@ -29,13 +35,25 @@ func Provider() *schema.Provider {
 			},
 		},
 		DataSourcesMap: map[string]*schema.Resource{
-			"waypoint_project":        dataSourceProject(),
-			"waypoint_runner_profile": dataSourceRunnerProfile(),
+			"waypoint_project":                dataSourceProject(),
+			"waypoint_runner_profile":         dataSourceRunnerProfile(),
+			"waypoint_default_runner_profile": dataSourceDefaultRunnerProfile(),
+			"waypoint_hostnames":              dataSourceHostnames(),
+			"waypoint_server_info":            dataSourceServerInfo(),
+			"waypoint_builds":                 dataSourceBuilds(),
+			"waypoint_deployments":            dataSourceDeployments(),
+			"waypoint_releases":               dataSourceReleases(),
+			"waypoint_status_report":          dataSourceStatusReport(),
+			"waypoint_auth_method":            dataSourceAuthMethod(),
+			"waypoint_auth_methods":           dataSourceAuthMethods(),
 		},
+		// waypoint_project is served by the framework provider, see
+		// NewFrameworkProvider.
 		ResourcesMap: map[string]*schema.Resource{
-			"waypoint_project":          resourceProject(),
 			"waypoint_runner_profile":   resourceRunnerProfile(),
 			"waypoint_auth_method_oidc": resourceAuthMethodOidc(),
+			"waypoint_hostname":         resourceHostname(),
+			"waypoint_job":              resourceJob(),
 		},
 	}
 
@@ -44,7 +62,13 @@ func Provider() *schema.Provider {
 			Token:        d.Get("token").(string),
 			WaypointAddr: d.Get("waypoint_addr").(string),
 		}
-		return config.Client()
+
+		client, err := clients.Client(config)
+		if err != nil {
+			return nil, diag.FromErr(err)
+		}
+
+		return client, nil
 	}
 
 	return provider
@@ -0,0 +1,142 @@
+package waypoint
+
+import (
+	"context"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/function"
+	"github.com/hashicorp/terraform-plugin-framework/provider"
+	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
+	"github.com/hashicorp/terraform-plugin-framework/providerserver"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-mux/tf5muxserver"
+)
+
+// frameworkProvider serves the resources and data sources that have been
+// migrated to terraform-plugin-framework. It is muxed with the SDKv2
+// provider returned by Provider, so both must declare the same provider
+// schema.
+type frameworkProvider struct {
+	version string
+
+	// clients holds the Waypoint client, shared with the SDKv2 provider
+	// when both are served by ProviderServer.
+	clients *sharedClient
+}
+
+type frameworkProviderModel struct {
+	Token        types.String `tfsdk:"token"`
+	WaypointAddr types.String `tfsdk:"waypoint_addr"`
+}
+
+var (
+	_ provider.Provider              = &frameworkProvider{}
+	_ provider.ProviderWithFunctions = &frameworkProvider{}
+)
+
+// NewFrameworkProvider returns a function that creates the
+// terraform-plugin-framework half of the provider.
+func NewFrameworkProvider(version string) func() provider.Provider {
+	return newFrameworkProvider(version, &sharedClient{})
+}
+
+func newFrameworkProvider(version string, clients *sharedClient) func() provider.Provider {
+	return func() provider.Provider {
+		return &frameworkProvider{
+			version: version,
+			clients: clients,
+		}
+	}
+}
+
+// ProviderServer returns a function that creates a protocol version 5
+// server muxing the SDKv2 and framework providers. Both use the same
+// Waypoint client, so the server is connected to and its version retrieved
+// only once.
+func ProviderServer(ctx context.Context, version string) (func() tfprotov5.ProviderServer, error) {
+	clients := &sharedClient{}
+
+	providers := []func() tfprotov5.ProviderServer{
+		newProvider(clients).GRPCProvider,
+		providerserver.NewProtocol5(newFrameworkProvider(version, clients)()),
+	}
+
+	muxServer, err := tf5muxserver.NewMuxServer(ctx, providers...)
+	if err != nil {
+		return nil, err
+	}
+
+	return muxServer.ProviderServer, nil
+}
+
+func (p *frameworkProvider) Metadata(ctx context.Context, req provider.MetadataRequest, resp *provider.MetadataResponse) {
+	resp.TypeName = "waypoint"
+	resp.Version = p.version
+}
+
+func (p *frameworkProvider) Schema(ctx context.Context, req provider.SchemaRequest, resp *provider.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Attributes: map[string]schema.Attribute{
+			"token": schema.StringAttribute{
+				Optional:    true,
+				Description: "Waypoint token to authenticate to Waypoint server",
+			},
+			"waypoint_addr": schema.StringAttribute{
+				Optional:    true,
+				Description: "Waypoint server address",
+			},
+		},
+	}
+}
+
+func (p *frameworkProvider) Configure(ctx context.Context, req provider.ConfigureRequest, resp *provider.ConfigureResponse) {
+	var data frameworkProviderModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	config := Config{
+		Token:        os.Getenv("WAYPOINT_TOKEN"),
+		WaypointAddr: os.Getenv("WAYPOINT_ADDR"),
+	}
+
+	if !data.Token.IsNull() {
+		config.Token = data.Token.ValueString()
+	}
+
+	if !data.WaypointAddr.IsNull() {
+		config.WaypointAddr = data.WaypointAddr.ValueString()
+	}
+
+	client, err := p.clients.Client(config)
+	if err != nil {
+		resp.Diagnostics.AddError("Unable to create Waypoint client", err.Error())
+		return
+	}
+
+	resp.DataSourceData = client
+	resp.ResourceData = client
+}
+
+func (p *frameworkProvider) Resources(ctx context.Context) []func() resource.Resource {
+	return []func() resource.Resource{
+		newProjectResource,
+		newProjectVariableResource,
+	}
+}
+
+func (p *frameworkProvider) DataSources(ctx context.Context) []func() datasource.DataSource {
+	return []func() datasource.DataSource{}
+}
+
+func (p *frameworkProvider) Functions(ctx context.Context) []func() function.Function {
+	return []func() function.Function{
+		newRunnerProfileConfigFunction,
+		newParseWaypointHclFunction,
+	}
+}
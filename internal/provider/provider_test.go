@@ -6,14 +6,49 @@ import (
 	"os"
 	"testing"
 
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
 
-// providerFactories are used to instantiate a provider during acceptance testing.
+// protoV5ProviderFactories are used to instantiate a provider during acceptance testing.
 // The factory function will be invoked for every Terraform CLI command executed
-// to create a provider server to which the CLI can reattach.
-var providerFactories = map[string]func() (*schema.Provider, error){
-	providerName: func() (*schema.Provider, error) { return Provider(), nil },
+// to create a provider server to which the CLI can reattach. The server muxes
+// the SDKv2 and framework providers, as in main.go.
+var protoV5ProviderFactories = map[string]func() (tfprotov5.ProviderServer, error){
+	providerName: func() (tfprotov5.ProviderServer, error) {
+		providerServer, err := ProviderServer(context.Background(), "test")
+		if err != nil {
+			return nil, err
+		}
+
+		return providerServer(), nil
+	},
+}
+
+func TestProviderServer(t *testing.T) {
+	providerServer, err := ProviderServer(context.Background(), "test")
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	// The mux server returns an error diagnostic when the SDKv2 and
+	// framework providers disagree on the provider schema, or a resource
+	// type is served by both.
+	resp, err := providerServer().GetProviderSchema(context.Background(), &tfprotov5.GetProviderSchemaRequest{})
+	if err != nil {
+		t.Fatalf("err: %s", err)
+	}
+
+	for _, d := range resp.Diagnostics {
+		if d.Severity == tfprotov5.DiagnosticSeverityError {
+			t.Errorf("%s: %s", d.Summary, d.Detail)
+		}
+	}
+
+	for _, name := range []string{"waypoint_project", "waypoint_project_variable"} {
+		if _, ok := resp.ResourceSchemas[name]; !ok {
+			t.Errorf("%s resource is not served", name)
+		}
+	}
 }
 
 //func TestProvider(t *testing.T) {
@@ -3,41 +3,40 @@ package waypoint
 import (
 	"context"
 	"fmt"
-	"github.com/hashicorp-dev-advocates/waypoint-client/pkg/client"
 	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
-	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/go-cty/cty"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
 )
 
 func resourceAuthMethodOidc() *schema.Resource {
-	return &schema.Resource{
-		Description: "Auth method OIDC resource manages OIDC auth methods in Waypoint.",
+	r := resourceAuthMethod(authMethodOidc(), "Auth method OIDC resource manages OIDC auth methods in Waypoint.")
 
-		CreateContext: resourceAuthMethodOidcCreate,
-		ReadContext:   resourceAuthMethodOidcRead,
-		UpdateContext: resourceAuthMethodOidcCreate,
-		DeleteContext: resourceAuthMethodOidcDelete,
-		Schema: map[string]*schema.Schema{
-			"name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "name of OIDC auth method",
-			},
-			"display_name": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Friendly display name of OIDC auth method",
-			},
-			"description": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Description of auth method",
-			},
+	r.CustomizeDiff = resourceAuthMethodOidcCustomizeDiff
+	r.ValidateRawResourceConfigFuncs = []schema.ValidateRawResourceConfigFunc{
+		validateAuthMethodOidcAccessorSelector,
+		validation.PreferWriteOnlyAttribute(cty.GetAttrPath("client_secret"), cty.GetAttrPath("client_secret_wo")),
+	}
+
+	return r
+}
+
+// authMethodOidc describes the OIDC auth methods.
+func authMethodOidc() authMethodKind {
+	return authMethodKind{
+		method:           "oidc",
+		minServerVersion: minServerVersionAuthMethodOidc,
+		expand:           expandAuthMethodOidc,
+		flatten:          flattenAuthMethodOidc,
+
+		schema: map[string]*schema.Schema{
 			"accessor_selector": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "",
+				Type:     schema.TypeString,
+				Optional: true,
+				Description: "Selector expression, in the go-bexpr syntax, that users must match to log in with the auth method. " +
+					"Claims are referenced as `value.<name>` for the names of `claim_mappings` and `list.<name>` for those of `list_claim_mappings`, " +
+					"for example `\"admin\" in list.groups`. The expression and the claims it references are validated at plan time",
 			},
 			"client_id": {
 				Type:        schema.TypeString,
@@ -45,10 +44,24 @@ func resourceAuthMethodOidc() *schema.Resource {
 				Description: "Client ID of OIDC provider",
 			},
 			"client_secret": {
-				Type:        schema.TypeString,
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "client secret for OIDC provider. It is stored in the Terraform state, use `client_secret_wo` to keep it out. It is not read back from the server, so changes made outside of Terraform are not detected",
+				Sensitive:     true,
+				ConflictsWith: []string{"client_secret_wo"},
+			},
+			"client_secret_wo": {
+				Type:          schema.TypeString,
+				Optional:      true,
+				Description:   "Client secret for OIDC provider, which is not stored in the Terraform state. Change `client_secret_version` to update it. Requires Terraform 1.11 or later",
+				Sensitive:     true,
+				WriteOnly:     true,
+				ConflictsWith: []string{"client_secret"},
+			},
+			"client_secret_version": {
+				Type:        schema.TypeInt,
 				Optional:    true,
-				Description: "client secret for OIDC provider",
-				Sensitive:   true,
+				Description: "Version of the client secret, to change when `client_secret_wo` changes so that the auth method is updated",
 			},
 			"discovery_url": {
 				Type:        schema.TypeString,
@@ -103,6 +116,14 @@ func resourceAuthMethodOidc() *schema.Resource {
 					Type: schema.TypeString,
 				},
 			},
+			"validate_discovery": {
+				Type:     schema.TypeBool,
+				Optional: true,
+				Default:  false,
+				Description: "Whether to fetch the OpenID configuration of `discovery_url` when planning, trusting `discovery_ca_pem`, " +
+					"and fail the plan if it cannot be fetched, if its issuer is not `discovery_url` or if it does not support `signing_algs` and `scopes`. " +
+					"Requires the machine running Terraform to reach the OIDC provider",
+			},
 			"auds": {
 				Type:        schema.TypeList,
 				Optional:    true,
@@ -115,156 +136,156 @@ func resourceAuthMethodOidc() *schema.Resource {
 	}
 }
 
-func resourceAuthMethodOidcCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	wp := m.(*WaypointClient).conn
-
-	authMethodConfig := client.DefaultAuthMethodConfig()
-
-	if name, ok := d.Get("name").(string); ok {
-		authMethodConfig.Name = name
+// resourceAuthMethodOidcCustomizeDiff validates the OIDC discovery
+// configuration against the issuer when validate_discovery is set.
+func resourceAuthMethodOidcCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.Get("validate_discovery").(bool) {
+		return nil
 	}
 
-	if displayName, ok := d.Get("display_name").(string); ok {
-		authMethodConfig.DisplayName = displayName
-	}
-
-	if description, ok := d.Get("description").(string); ok {
-		authMethodConfig.Description = description
+	// Values computed from other resources are validated once known.
+	for _, key := range []string{"discovery_url", "discovery_ca_pem", "signing_algs", "scopes"} {
+		if !d.NewValueKnown(key) {
+			return nil
+		}
 	}
 
-	if accessorSelector, ok := d.Get("accessor_selector").(string); ok {
-		authMethodConfig.AccessSelector = accessorSelector
+	err := validateOidcDiscovery(
+		ctx,
+		d.Get("discovery_url").(string),
+		expandStringList(d.Get("discovery_ca_pem").([]interface{})),
+		expandStringList(d.Get("signing_algs").([]interface{})),
+		expandStringList(d.Get("scopes").([]interface{})),
+	)
+	if err != nil {
+		return fmt.Errorf("OIDC discovery validation failed: %w", err)
 	}
 
-	oidcConfig := client.DefaultOidcConfig()
-	oidcConfig.ClientId = d.Get("client_id").(string)
-	oidcConfig.DiscoveryUrl = d.Get("discovery_url").(string)
+	return nil
+}
 
-	if clientSecret, ok := d.Get("client_secret").(string); ok {
-		oidcConfig.ClientSecret = clientSecret
+// expandStringList converts a list attribute to a slice of strings.
+func expandStringList(list []interface{}) []string {
+	strs := make([]string, len(list))
+	for i, v := range list {
+		strs[i] = fmt.Sprint(v)
 	}
 
-	redirectUrls := d.Get("allowed_redirect_urls").([]interface{})
-	strRedirectUrl := make([]string, len(redirectUrls))
+	return strs
+}
 
-	for i, v := range redirectUrls {
-		strRedirectUrl[i] = fmt.Sprint(v)
+// expandStringMap converts a map attribute to a map of strings.
+func expandStringMap(m map[string]interface{}) map[string]string {
+	strs := make(map[string]string, len(m))
+	for k, v := range m {
+		strs[k] = fmt.Sprint(v)
 	}
 
-	oidcConfig.AllowedRedirectUris = strRedirectUrl
-	if claimMappings, ok := d.Get("claim_mappings").(map[string]interface{}); ok {
-		cMappings := make(map[string]string)
-
-		for k, v := range claimMappings {
-			strKey := fmt.Sprintf("%v", k)
-			strValue := fmt.Sprintf("%v", v)
-			cMappings[strKey] = strValue
-		}
+	return strs
+}
 
-		oidcConfig.ClaimMappings = cMappings
+// validateAuthMethodOidcAccessorSelector parses accessor_selector and
+// checks the claims it references are mapped, as a selector that cannot be
+// evaluated locks every user out of the auth method.
+func validateAuthMethodOidcAccessorSelector(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
 	}
 
-	if listClaimMappings, ok := d.Get("list_claim_mappings").(map[string]interface{}); ok {
-		mappings := make(map[string]string)
-
-		for k, v := range listClaimMappings {
-			strKey := fmt.Sprintf("%v", k)
-			strValue := fmt.Sprintf("%v", v)
-			mappings[strKey] = strValue
-		}
-
-		oidcConfig.ListClaimMappings = mappings
+	selector := req.RawConfig.GetAttr("accessor_selector")
+	if !selector.IsKnown() || selector.IsNull() || selector.AsString() == "" {
+		return
 	}
 
-	if audience, ok := d.Get("auds").([]interface{}); ok {
-		audSlice := make([]string, len(audience))
-
-		for i, v := range audience {
-			audSlice[i] = fmt.Sprint(v)
-		}
-
-		oidcConfig.Auds = audSlice
+	for _, e := range validateAccessSelector(
+		selector.AsString(),
+		claimMappingsValue(req.RawConfig.GetAttr("claim_mappings")),
+		claimMappingsValue(req.RawConfig.GetAttr("list_claim_mappings")),
+	) {
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid accessor_selector",
+			Detail:        e.Detail(selector.AsString()),
+			AttributePath: cty.GetAttrPath("accessor_selector"),
+		})
 	}
+}
 
-	if scopes, ok := d.Get("scopes").([]interface{}); ok {
-		scopeSlice := make([]string, len(scopes))
-
-		for i, v := range scopes {
-			scopeSlice[i] = fmt.Sprint(v)
-		}
-
-		oidcConfig.Scopes = scopeSlice
+// claimMappingsValue returns the claim mappings held by v, or nil when they
+// are not known yet. Unset mappings are empty.
+func claimMappingsValue(v cty.Value) map[string]string {
+	if !v.IsWhollyKnown() {
+		return nil
 	}
 
-	if signingAlgos, ok := d.Get("signing_algs").([]interface{}); ok {
-		//signingAlgs := d.Get("signing_algs").([]interface{})
-		signingAlgsList := make([]string, len(signingAlgos))
-
-		for i, value := range signingAlgos {
-			signingAlgsList[i] = fmt.Sprint(value)
-		}
-
-		oidcConfig.SigningAlgs = signingAlgsList
-
+	mappings := map[string]string{}
+	if v.IsNull() {
+		return mappings
 	}
 
-	if discoveryCaPem, ok := d.Get("discovery_ca_pem").([]interface{}); ok {
-		discoveryCaPemSlice := make([]string, len(discoveryCaPem))
-
-		for i, v := range discoveryCaPem {
-			discoveryCaPemSlice[i] = fmt.Sprint(v)
+	for key, value := range v.AsValueMap() {
+		if !value.IsNull() {
+			mappings[key] = value.AsString()
 		}
-
-		oidcConfig.DiscoveryCaPem = discoveryCaPemSlice
 	}
 
-	authMethod, err := wp.UpsertOidc(context.TODO(), oidcConfig, authMethodConfig)
-	if err != nil {
-		return diag.FromErr(err)
-	}
+	return mappings
+}
 
-	d.SetId(authMethod.Name)
+// expandAuthMethodOidc sets the OIDC configuration of am from d.
+func expandAuthMethodOidc(d *schema.ResourceData, am *gen.AuthMethod) diag.Diagnostics {
+	clientSecret, diags := authMethodOidcClientSecret(d)
+	if diags.HasError() {
+		return diags
+	}
 
-	tflog.Trace(ctx, "created a resource")
-	tflog.Debug(ctx, fmt.Sprintf("%s", oidcConfig))
+	am.Method = &gen.AuthMethod_Oidc{Oidc: &gen.AuthMethod_OIDC{
+		ClientId:            d.Get("client_id").(string),
+		ClientSecret:        clientSecret,
+		DiscoveryUrl:        d.Get("discovery_url").(string),
+		AllowedRedirectUris: expandStringList(d.Get("allowed_redirect_urls").([]interface{})),
+		ClaimMappings:       expandStringMap(d.Get("claim_mappings").(map[string]interface{})),
+		ListClaimMappings:   expandStringMap(d.Get("list_claim_mappings").(map[string]interface{})),
+		Auds:                expandStringList(d.Get("auds").([]interface{})),
+		Scopes:              expandStringList(d.Get("scopes").([]interface{})),
+		SigningAlgs:         expandStringList(d.Get("signing_algs").([]interface{})),
+		DiscoveryCaPem:      expandStringList(d.Get("discovery_ca_pem").([]interface{})),
+	}}
 
-	return resourceAuthMethodOidcRead(ctx, d, m)
+	return nil
 }
 
-func resourceAuthMethodOidcRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	wp := m.(*WaypointClient).conn
-
-	name := d.Get("name").(string)
-	am, err := wp.GetOidcAuthMethod(context.TODO(), name)
-	if err != nil {
-		return diag.FromErr(err)
+// authMethodOidcClientSecret returns the client secret from
+// client_secret_wo, which is only available in the configuration, or else
+// from client_secret.
+func authMethodOidcClientSecret(d *schema.ResourceData) (string, diag.Diagnostics) {
+	clientSecretWo, diags := d.GetRawConfigAt(cty.GetAttrPath("client_secret_wo"))
+	if diags.HasError() {
+		return "", diags
 	}
 
-	//am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.SigningAlgs
-	d.SetId(name)
-	d.Set("display_name", am.AuthMethod.DisplayName)
-	d.Set("description", am.AuthMethod.Description)
-	d.Set("accessor_selector", am.AuthMethod.AccessSelector)
-	d.Set("client_id", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.ClientId)
-	d.Set("client_secret", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.ClientSecret)
-	d.Set("discovery_url", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.DiscoveryUrl)
-	d.Set("allowed_redirect_urls", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.AllowedRedirectUris)
-	d.Set("claim_mappings", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.ClaimMappings)
-	d.Set("list_claim_mappings", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.ListClaimMappings)
-	d.Set("discovery_ca_pem", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.DiscoveryCaPem)
-	d.Set("signing_algs", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.SigningAlgs)
-	d.Set("scopes", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.Scopes)
-	d.Set("auds", am.AuthMethod.Method.(*gen.AuthMethod_Oidc).Oidc.Auds)
+	if clientSecretWo.Type().Equals(cty.String) && clientSecretWo.IsKnown() && !clientSecretWo.IsNull() {
+		return clientSecretWo.AsString(), nil
+	}
 
-	return nil
+	return d.Get("client_secret").(string), nil
 }
 
-func resourceAuthMethodOidcDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	wp := m.(*WaypointClient).conn
+// flattenAuthMethodOidc sets the OIDC attributes of d from am.
+func flattenAuthMethodOidc(d *schema.ResourceData, am *gen.AuthMethod) diag.Diagnostics {
+	oidc := am.GetOidc()
+
+	// client_secret is left as configured: the server may redact it, and
+	// reading it back would store a secret set with client_secret_wo.
+	d.Set("client_id", oidc.ClientId)
+	d.Set("discovery_url", oidc.DiscoveryUrl)
+	d.Set("allowed_redirect_urls", oidc.AllowedRedirectUris)
+	d.Set("claim_mappings", oidc.ClaimMappings)
+	d.Set("list_claim_mappings", oidc.ListClaimMappings)
+	d.Set("discovery_ca_pem", oidc.DiscoveryCaPem)
+	d.Set("signing_algs", oidc.SigningAlgs)
+	d.Set("scopes", oidc.Scopes)
+	d.Set("auds", oidc.Auds)
 
-	err := wp.DeleteOidc(context.TODO(), d.Get("name").(string))
-	if err != nil {
-		return diag.FromErr(err)
-	}
 	return nil
 }
@@ -5,6 +5,7 @@ import (
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
 	"testing"
 )
 
@@ -12,9 +13,9 @@ func TestAccWaypointAuthMethodOidc(t *testing.T) {
 	amName := sdkacctest.RandomWithPrefix(providerName)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		CheckDestroy:      testAccCheckAuthMethodOidcDestroy,
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckAuthMethodOidcDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccAuthMethodOidc(amName),
@@ -58,6 +59,61 @@ func TestAccWaypointAuthMethodOidc(t *testing.T) {
 	)
 }
 
+func TestAccWaypointAuthMethodOidcInvalidSelector(t *testing.T) {
+	amName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config:      testAccAuthMethodOidcSelector(amName, `"admin" in list.grops`),
+				PlanOnly:    true,
+				ExpectError: regexp.MustCompile(`Unknown claim "grops"`),
+			},
+		},
+	},
+	)
+}
+
+func TestAccWaypointAuthMethodOidcClientSecretWo(t *testing.T) {
+	amName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckAuthMethodOidcDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccAuthMethodOidcClientSecretWo(amName, "first-secret", 1),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(
+						"waypoint_auth_method_oidc.test", "client_secret_wo"),
+					resource.TestCheckResourceAttr(
+						"waypoint_auth_method_oidc.test", "client_secret", ""),
+					resource.TestCheckResourceAttr(
+						"waypoint_auth_method_oidc.test", "client_secret_version", "1"),
+				),
+			},
+			{
+				// A new secret alone does not plan an update.
+				Config:   testAccAuthMethodOidcClientSecretWo(amName, "second-secret", 1),
+				PlanOnly: true,
+			},
+			{
+				Config: testAccAuthMethodOidcClientSecretWo(amName, "second-secret", 2),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckNoResourceAttr(
+						"waypoint_auth_method_oidc.test", "client_secret_wo"),
+					resource.TestCheckResourceAttr(
+						"waypoint_auth_method_oidc.test", "client_secret_version", "2"),
+				),
+			},
+		},
+	},
+	)
+}
+
 func testAccCheckAuthMethodOidcDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "auth_method_oidc" {
@@ -115,3 +171,39 @@ resource "waypoint_auth_method_oidc" "test" {
 }
 `, name)
 }
+
+func testAccAuthMethodOidcSelector(name, selector string) string {
+	return fmt.Sprintf(`
+resource "waypoint_auth_method_oidc" "test" {
+  name          = %q
+  client_id     = "060d0801-6fv7-4b03-ad59-b6397e2hc4ad"
+  discovery_url = "https://login.microsoftonline.com/<insert-tenant-ID-here>/v2.0"
+
+  allowed_redirect_urls = [
+    "http://localhost:9701/oidc/callback"
+  ]
+
+  list_claim_mappings = {
+    groups = "groups"
+  }
+
+  accessor_selector = %q
+}
+`, name, selector)
+}
+
+func testAccAuthMethodOidcClientSecretWo(name, clientSecret string, version int) string {
+	return fmt.Sprintf(`
+resource "waypoint_auth_method_oidc" "test" {
+  name                  = %q
+  client_id             = "060d0801-6fv7-4b03-ad59-b6397e2hc4ad"
+  client_secret_wo      = %q
+  client_secret_version = %d
+  discovery_url         = "https://login.microsoftonline.com/<insert-tenant-ID-here>/v2.0"
+
+  allowed_redirect_urls = [
+    "http://localhost:9701/oidc/callback"
+  ]
+}
+`, name, clientSecret, version)
+}
@@ -0,0 +1,145 @@
+package waypoint
+
+import (
+	"context"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func resourceHostname() *schema.Resource {
+	return &schema.Resource{
+		Description: "Hostname resource registers a Waypoint URL service hostname for an application.",
+
+		CreateContext: resourceHostnameCreate,
+		ReadContext:   resourceHostnameRead,
+		DeleteContext: resourceHostnameDelete,
+
+		Schema: map[string]*schema.Schema{
+			"hostname": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Computed:    true,
+				ForceNew:    true,
+				Description: "The hostname to register, such as `fabulous-panda`. If not set, Waypoint generates one.",
+			},
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Waypoint project the hostname routes to",
+			},
+			"application_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the application the hostname routes to",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				ForceNew:    true,
+				Description: "The workspace of the application the hostname routes to",
+			},
+			"fqdn": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "Fully qualified domain name of the hostname, such as `fabulous-panda.waypoint.run`",
+			},
+			"target_labels": {
+				Type:        schema.TypeMap,
+				Computed:    true,
+				Description: "The raw label targets given to the URL service",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+		},
+	}
+}
+
+func resourceHostnameCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	chr := &gen.CreateHostnameRequest{
+		Hostname: d.Get("hostname").(string),
+		Target:   hostnameTarget(d),
+	}
+
+	hostname, err := wp.GRPCClient().CreateHostname(ctx, chr)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.SetId(hostname.Hostname.Hostname)
+
+	tflog.Trace(ctx, "created a resource")
+
+	return resourceHostnameRead(ctx, d, m)
+}
+
+func resourceHostnameRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	lhr := &gen.ListHostnamesRequest{
+		Target: hostnameTarget(d),
+	}
+
+	hostnames, err := wp.GRPCClient().ListHostnames(ctx, lhr)
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	for _, hostname := range hostnames.Hostnames {
+		if hostname.Hostname != d.Id() {
+			continue
+		}
+
+		d.Set("hostname", hostname.Hostname)
+		d.Set("fqdn", hostname.Fqdn)
+		d.Set("target_labels", hostname.TargetLabels)
+
+		return nil
+	}
+
+	// The hostname no longer exists on the server, so remove it from state
+	// and let Terraform plan to create it again.
+	tflog.Warn(ctx, "hostname not found, removing from state", map[string]interface{}{
+		"hostname": d.Id(),
+	})
+	d.SetId("")
+
+	return nil
+}
+
+func resourceHostnameDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	_, err := wp.GRPCClient().DeleteHostname(ctx, &gen.DeleteHostnameRequest{
+		Hostname: d.Id(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+
+	return nil
+}
+
+// hostnameTarget builds the URL service target for the application
+// referenced by the project_name, application_name and workspace_name
+// attributes.
+func hostnameTarget(d *schema.ResourceData) *gen.Hostname_Target {
+	return &gen.Hostname_Target{
+		Target: &gen.Hostname_Target_Application{
+			Application: &gen.Hostname_TargetApp{
+				Application: applicationRef(d),
+				Workspace:   workspaceRef(d),
+			},
+		},
+	}
+}
@@ -0,0 +1,60 @@
+package waypoint
+
+import (
+	"fmt"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"testing"
+)
+
+func TestAccWaypointHostname(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckHostnameDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccHostname(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_hostname.test", "hostname", rName),
+					resource.TestCheckResourceAttr(
+						"waypoint_hostname.test", "project_name", "example"),
+					resource.TestCheckResourceAttr(
+						"waypoint_hostname.test", "application_name", "example-nodejs"),
+					resource.TestCheckResourceAttr(
+						"waypoint_hostname.test", "workspace_name", "default"),
+					resource.TestMatchResourceAttr(
+						"waypoint_hostname.test", "fqdn", regexp.MustCompile("^"+rName+"\\.")),
+					resource.TestCheckResourceAttr(
+						"waypoint_hostname.test", "target_labels.waypoint.hashicorp.com/app", "example-nodejs"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckHostnameDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "waypoint_hostname" {
+			continue
+		}
+
+		// check that your destroy logic has executed if not return an error
+	}
+
+	return nil
+}
+
+func testAccHostname(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_hostname" "test" {
+  hostname         = "%s"
+  project_name     = "example"
+  application_name = "example-nodejs"
+}`, name)
+}
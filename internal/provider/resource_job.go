@@ -0,0 +1,344 @@
+package waypoint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// jobCleanupTimeout bounds the calls made after a job failed or timed out,
+// to cancel it and record its state.
+const jobCleanupTimeout = 30 * time.Second
+
+func resourceJob() *schema.Resource {
+	return &schema.Resource{
+		Description: "Job resource queues a Waypoint operation for an application and waits for it to complete. " +
+			"The operation runs again whenever any argument, including `triggers`, changes.",
+
+		CreateContext: resourceJobCreate,
+		ReadContext:   resourceJobRead,
+		DeleteContext: resourceJobDelete,
+
+		Timeouts: &schema.ResourceTimeout{
+			Create: schema.DefaultTimeout(30 * time.Minute),
+		},
+
+		Schema: map[string]*schema.Schema{
+			"project_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the Waypoint project",
+			},
+			"application_name": {
+				Type:        schema.TypeString,
+				Required:    true,
+				ForceNew:    true,
+				Description: "The name of the application in the Waypoint project",
+			},
+			"workspace_name": {
+				Type:        schema.TypeString,
+				Optional:    true,
+				Default:     "default",
+				ForceNew:    true,
+				Description: "The workspace to run the operation in",
+			},
+			"operation": {
+				Type:         schema.TypeString,
+				Required:     true,
+				ForceNew:     true,
+				ValidateFunc: validation.StringInSlice([]string{"up", "build", "deploy", "release"}, false),
+				Description: "The operation to run, one of `up`, `build`, `deploy` or `release`. " +
+					"`deploy` deploys the latest pushed artifact and `release` releases the latest successful deployment.",
+			},
+			"variables": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Input variable values for the operation, as if set with `-var` on the CLI",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"triggers": {
+				Type:        schema.TypeMap,
+				Optional:    true,
+				ForceNew:    true,
+				Description: "Arbitrary map of values that, when changed, will run the operation again",
+				Elem: &schema.Schema{
+					Type: schema.TypeString,
+				},
+			},
+			"state": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "State of the job, such as `SUCCESS` or `ERROR`",
+			},
+			"build_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the build created by the job",
+			},
+			"deployment_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the deployment created by the job",
+			},
+			"deployment_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the deployment created by the job",
+			},
+			"release_id": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "ID of the release created by the job",
+			},
+			"release_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the release created by the job",
+			},
+			"app_url": {
+				Type:        schema.TypeString,
+				Computed:    true,
+				Description: "URL of the application, set by the `up` operation when the URL service is enabled",
+			},
+		},
+	}
+}
+
+func resourceJobCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	ctx, cancel := context.WithTimeout(ctx, d.Timeout(schema.TimeoutCreate))
+	defer cancel()
+
+	project, err := wp.GetProject(ctx, d.Get("project_name").(string))
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	job := &gen.Job{
+		Application:  applicationRef(d),
+		Workspace:    workspaceRef(d),
+		TargetRunner: &gen.Ref_Runner{Target: &gen.Ref_Runner_Any{Any: &gen.Ref_RunnerAny{}}},
+		DataSource:   project.DataSource,
+	}
+
+	for key, value := range d.Get("variables").(map[string]interface{}) {
+		job.Variables = append(job.Variables, &gen.Variable{
+			Name:   key,
+			Value:  &gen.Variable_Str{Str: value.(string)},
+			Source: &gen.Variable_Cli{Cli: &emptypb.Empty{}},
+		})
+	}
+
+	switch d.Get("operation").(string) {
+	case "up":
+		job.Operation = &gen.Job_Up{Up: &gen.Job_UpOp{
+			Release: &gen.Job_ReleaseOp{Prune: true},
+		}}
+	case "build":
+		job.Operation = &gen.Job_Build{Build: &gen.Job_BuildOp{}}
+	case "deploy":
+		artifact, err := wp.GRPCClient().GetLatestPushedArtifact(ctx, &gen.GetLatestPushedArtifactRequest{
+			Application: job.Application,
+			Workspace:   job.Workspace,
+		})
+		if err != nil {
+			return diag.Errorf("Error retrieving the latest pushed artifact to deploy: %s", err)
+		}
+
+		job.Operation = &gen.Job_Deploy{Deploy: &gen.Job_DeployOp{Artifact: artifact}}
+	case "release":
+		ldr, err := wp.GRPCClient().ListDeployments(ctx, &gen.ListDeploymentsRequest{
+			Application:   job.Application,
+			Workspace:     job.Workspace,
+			PhysicalState: gen.Operation_CREATED,
+			Status: []*gen.StatusFilter{{
+				Filters: []*gen.StatusFilter_Filter{{
+					Filter: &gen.StatusFilter_Filter_State{State: gen.Status_SUCCESS},
+				}},
+			}},
+			Order: &gen.OperationOrder{
+				Order: gen.OperationOrder_COMPLETE_TIME,
+				Desc:  true,
+				Limit: 1,
+			},
+		})
+		if err != nil {
+			return diag.Errorf("Error retrieving the latest deployment to release: %s", err)
+		}
+		if len(ldr.Deployments) == 0 {
+			return diag.Errorf("No successful deployment of %s to release", job.Application.Application)
+		}
+
+		job.Operation = &gen.Job_Release{Release: &gen.Job_ReleaseOp{
+			Deployment: ldr.Deployments[0],
+			Prune:      true,
+		}}
+	}
+
+	qjr, err := wp.GRPCClient().QueueJob(ctx, &gen.QueueJobRequest{
+		Job:       job,
+		ExpiresIn: d.Timeout(schema.TimeoutCreate).String(),
+	})
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	// The ID is set before waiting, so that a job which fails or times out is
+	// tainted and run again on the next apply.
+	d.SetId(qjr.JobId)
+
+	tflog.Info(ctx, "queued Waypoint job", map[string]interface{}{
+		"job_id":    qjr.JobId,
+		"operation": d.Get("operation").(string),
+	})
+
+	if err := waitForJob(ctx, wp.GRPCClient(), qjr.JobId); err != nil {
+		// ctx may have expired, so the cleanup keeps its values but gets a
+		// deadline of its own.
+		cleanupCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), jobCleanupTimeout)
+		defer cancel()
+
+		if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			// Don't leave the job running on the server once Terraform has
+			// given up on it.
+			_, _ = wp.GRPCClient().CancelJob(cleanupCtx, &gen.CancelJobRequest{JobId: qjr.JobId})
+		}
+
+		diags := diag.Errorf("Waypoint job %s failed: %s", qjr.JobId, err)
+
+		return append(diags, resourceJobRead(cleanupCtx, d, m)...)
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	return resourceJobRead(ctx, d, m)
+}
+
+func resourceJobRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	wp := m.(*WaypointClient).conn
+
+	job, err := wp.GRPCClient().GetJob(ctx, &gen.GetJobRequest{JobId: d.Id()})
+	if status.Code(err) == codes.NotFound {
+		// Completed jobs are eventually pruned by the server. The job has
+		// already run, so keep the recorded results rather than running it
+		// again.
+		return nil
+	}
+	if err != nil {
+		return diag.FromErr(err)
+	}
+
+	d.Set("state", job.State.String())
+
+	result := job.GetResult()
+	d.Set("build_id", result.GetBuild().GetBuild().GetId())
+	d.Set("deployment_id", result.GetDeploy().GetDeployment().GetId())
+	d.Set("release_id", result.GetRelease().GetRelease().GetId())
+
+	deploymentUrl := result.GetDeploy().GetDeployment().GetUrl()
+	if deploymentUrl == "" {
+		deploymentUrl = result.GetUp().GetDeployUrl()
+	}
+	d.Set("deployment_url", deploymentUrl)
+
+	releaseUrl := result.GetRelease().GetRelease().GetUrl()
+	if releaseUrl == "" {
+		releaseUrl = result.GetUp().GetReleaseUrl()
+	}
+	d.Set("release_url", releaseUrl)
+
+	d.Set("app_url", result.GetUp().GetAppUrl())
+
+	return nil
+}
+
+func resourceJobDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+	// A completed job cannot be undone, so deleting only removes it from state.
+	d.SetId("")
+
+	return nil
+}
+
+// waitForJob streams the events of a queued job into the Terraform logs until
+// the job completes, returning the job's error if it failed.
+func waitForJob(ctx context.Context, wp gen.WaypointClient, jobId string) error {
+	stream, err := wp.GetJobStream(ctx, &gen.GetJobStreamRequest{JobId: jobId})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := stream.Recv()
+		if err == io.EOF {
+			return fmt.Errorf("job stream closed before the job completed")
+		}
+		if err != nil {
+			return err
+		}
+
+		switch event := resp.Event.(type) {
+		case *gen.GetJobStreamResponse_State_:
+			tflog.Debug(ctx, "Waypoint job state changed", map[string]interface{}{
+				"job_id":   jobId,
+				"previous": event.State.Previous.String(),
+				"current":  event.State.Current.String(),
+			})
+		case *gen.GetJobStreamResponse_Terminal_:
+			for _, terminalEvent := range event.Terminal.Events {
+				logTerminalEvent(ctx, jobId, terminalEvent)
+			}
+		case *gen.GetJobStreamResponse_Error_:
+			return status.FromProto(event.Error.Error).Err()
+		case *gen.GetJobStreamResponse_Complete_:
+			if event.Complete.Error != nil {
+				return status.FromProto(event.Complete.Error).Err()
+			}
+
+			return nil
+		}
+	}
+}
+
+func logTerminalEvent(ctx context.Context, jobId string, event *gen.GetJobStreamResponse_Terminal_Event) {
+	fields := map[string]interface{}{"job_id": jobId}
+
+	switch e := event.Event.(type) {
+	case *gen.GetJobStreamResponse_Terminal_Event_Line_:
+		tflog.Info(ctx, e.Line.Msg, fields)
+	case *gen.GetJobStreamResponse_Terminal_Event_Status_:
+		tflog.Info(ctx, e.Status.Msg, fields)
+	case *gen.GetJobStreamResponse_Terminal_Event_Step_:
+		if e.Step.Msg != "" {
+			tflog.Info(ctx, e.Step.Msg, fields)
+		}
+		if len(e.Step.Output) > 0 {
+			tflog.Info(ctx, string(e.Step.Output), fields)
+		}
+	case *gen.GetJobStreamResponse_Terminal_Event_Raw_:
+		if e.Raw.Stderr {
+			tflog.Warn(ctx, string(e.Raw.Data), fields)
+		} else {
+			tflog.Info(ctx, string(e.Raw.Data), fields)
+		}
+	case *gen.GetJobStreamResponse_Terminal_Event_NamedValues_:
+		for _, value := range e.NamedValues.Values {
+			tflog.Info(ctx, value.Name+": "+value.Value, fields)
+		}
+	}
+}
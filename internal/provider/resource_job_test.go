@@ -0,0 +1,82 @@
+package waypoint
+
+import (
+	"fmt"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"regexp"
+	"testing"
+)
+
+func TestAccWaypointJobUp(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckJobDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccJobUp(rName, "1"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_job.test", "operation", "up"),
+					resource.TestCheckResourceAttr(
+						"waypoint_job.test", "state", "SUCCESS"),
+					resource.TestMatchResourceAttr(
+						"waypoint_job.test", "build_id", regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr(
+						"waypoint_job.test", "deployment_id", regexp.MustCompile(".+")),
+					resource.TestMatchResourceAttr(
+						"waypoint_job.test", "release_id", regexp.MustCompile(".+")),
+				),
+			},
+			{
+				Config: testAccJobUp(rName, "2"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_job.test", "triggers.version", "2"),
+					resource.TestCheckResourceAttr(
+						"waypoint_job.test", "state", "SUCCESS"),
+				),
+			},
+		},
+	})
+}
+
+func testAccCheckJobDestroy(s *terraform.State) error {
+	for _, rs := range s.RootModule().Resources {
+		if rs.Type != "waypoint_job" {
+			continue
+		}
+
+		// check that your destroy logic has executed if not return an error
+	}
+
+	return nil
+}
+
+func testAccJobUp(name string, version string) string {
+	return fmt.Sprintf(`
+resource "waypoint_project" "test" {
+  project_name           = "%s"
+  remote_runners_enabled = true
+
+  data_source_git {
+    git_url  = "https://github.com/hashicorp/waypoint-examples"
+    git_path = "docker/nodejs"
+    git_ref  = "HEAD"
+  }
+}
+
+resource "waypoint_job" "test" {
+  project_name     = waypoint_project.test.project_name
+  application_name = "example-nodejs"
+  operation        = "up"
+
+  triggers = {
+    version = "%s"
+  }
+}`, name, version)
+}
@@ -2,339 +2,1325 @@ package waypoint
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp-dev-advocates/waypoint-client/pkg/client"
 	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
-func resourceProject() *schema.Resource {
-	return &schema.Resource{
-		// This description is used by the documentation generator and the language server.
-		Description: "Project resource in the Waypoint Terraform provider.",
+// projectResource is the first resource served by the framework provider.
+// Its schema keeps the attribute names, types and nesting of the SDKv2
+// resource it replaced, so state written by earlier versions of the
+// provider is read without an upgrade. Optional attributes the server
+// always reports are also Computed with a zero default, matching how the
+// SDKv2 stored them.
+type projectResource struct {
+	client *WaypointClient
+}
+
+type projectResourceModel struct {
+	Id                   types.String                `tfsdk:"id"`
+	ProjectName          types.String                `tfsdk:"project_name"`
+	ProjectVariables     types.Map                   `tfsdk:"project_variables"`
+	DataSourceGit        []projectDataSourceGitModel `tfsdk:"data_source_git"`
+	RemoteRunnersEnabled types.Bool                  `tfsdk:"remote_runners_enabled"`
+	GitAuthBasic         []projectGitAuthBasicModel  `tfsdk:"git_auth_basic"`
+	GitAuthSsh           []projectGitAuthSshModel    `tfsdk:"git_auth_ssh"`
+	AppStatusPollSeconds types.Int64                 `tfsdk:"app_status_poll_seconds"`
+	StatusReportPoll     []projectPollModel          `tfsdk:"status_report_poll"`
+	DataSourcePoll       []projectPollModel          `tfsdk:"data_source_poll"`
+	WaypointHcl          types.String                `tfsdk:"waypoint_hcl"`
+	WaypointHclFormat    types.String                `tfsdk:"waypoint_hcl_format"`
+}
+
+type projectPollModel struct {
+	Enabled  types.Bool   `tfsdk:"enabled"`
+	Interval types.String `tfsdk:"interval"`
+}
 
-		CreateContext: resourceProjectCreate,
-		ReadContext:   resourceProjectRead,
-		UpdateContext: resourceProjectCreate,
-		DeleteContext: resourceProjectDelete,
+type projectDataSourceGitModel struct {
+	GitUrl                   types.String `tfsdk:"git_url"`
+	GitPath                  types.String `tfsdk:"git_path"`
+	GitRef                   types.String `tfsdk:"git_ref"`
+	IgnoreChangesOutsidePath types.Bool   `tfsdk:"ignore_changes_outside_path"`
+	GitPollIntervalSeconds   types.Int64  `tfsdk:"git_poll_interval_seconds"`
+	FileChangeSignal         types.String `tfsdk:"file_change_signal"`
+}
+
+type projectGitAuthBasicModel struct {
+	Username           types.String `tfsdk:"username"`
+	Password           types.String `tfsdk:"password"`
+	PasswordWo         types.String `tfsdk:"password_wo"`
+	PasswordFile       types.String `tfsdk:"password_file"`
+	CredentialsVersion types.Int64  `tfsdk:"credentials_version"`
+	Fingerprint        types.String `tfsdk:"credentials_fingerprint"`
+}
 
-		Schema: map[string]*schema.Schema{
-			"project_name": {
-				Type:        schema.TypeString,
+type projectGitAuthSshModel struct {
+	GitUser            types.String `tfsdk:"git_user"`
+	Passphrase         types.String `tfsdk:"passphrase"`
+	PassphraseWo       types.String `tfsdk:"passphrase_wo"`
+	SshPrivateKey      types.String `tfsdk:"ssh_private_key"`
+	SshPrivateKeyWo    types.String `tfsdk:"ssh_private_key_wo"`
+	SshPrivateKeyFile  types.String `tfsdk:"ssh_private_key_file"`
+	CredentialsVersion types.Int64  `tfsdk:"credentials_version"`
+	Fingerprint        types.String `tfsdk:"credentials_fingerprint"`
+}
+
+var (
+	_ resource.Resource                   = &projectResource{}
+	_ resource.ResourceWithConfigure      = &projectResource{}
+	_ resource.ResourceWithValidateConfig = &projectResource{}
+	_ resource.ResourceWithModifyPlan     = &projectResource{}
+)
+
+func newProjectResource() resource.Resource {
+	return &projectResource{}
+}
+
+func (r *projectResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+func (r *projectResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// This description is used by the documentation generator and the language server.
+		//
+		// The Project message of the Waypoint API this provider is built
+		// against has no on-demand runner field, only jobs reference a runner
+		// profile, so a project cannot be pinned to a runner profile here.
+		// Neither does that API have project templates, so projects cannot
+		// be created from a template.
+		Description: "Project resource in the Waypoint Terraform provider. " +
+			"Remote operations of the project run with the default runner profile, " +
+			"see the `waypoint_default_runner_profile` data source, unless the `runner` stanza of its waypoint.hcl names a profile.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The name of the Waypoint project",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_name": schema.StringAttribute{
 				Required:    true,
 				Description: "The name of the Waypoint project",
 			},
-			"project_variables": &schema.Schema{
-				Type:        schema.TypeMap,
+			"project_variables": schema.MapAttribute{
+				ElementType: types.StringType,
+				Optional:    true,
+				Description: "List of variables in Key/value pairs associated with the Waypoint Project. Other variables of the project, such as those of `waypoint_project_variable` resources, are left untouched",
+			},
+			"remote_runners_enabled": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Enable remote runners for project",
+			},
+			"waypoint_hcl": schema.StringAttribute{
+				Optional: true,
+				Description: "Contents of the waypoint.hcl file of the project, used when its git repository has none. " +
+					"It is validated at plan time, and differences in formatting, comments and key order are ignored.",
+			},
+			"waypoint_hcl_format": schema.StringAttribute{
 				Optional:    true,
-				Description: "List of variables in Key/value pairs associated with the Waypoint Project",
-				Elem: &schema.Schema{
-					Type: schema.TypeString,
+				Computed:    true,
+				Default:     stringdefault.StaticString("hcl"),
+				Description: "Syntax of `waypoint_hcl`, `hcl` or `json`. It can only be set together with `waypoint_hcl`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(waypointHclFormats...),
+					stringvalidator.AlsoRequires(path.MatchRoot("waypoint_hcl")),
 				},
 			},
-			"data_source_git": &schema.Schema{
-				Type:        schema.TypeList,
-				MaxItems:    1,
-				Required:    true,
+			"app_status_poll_seconds": schema.Int64Attribute{
+				Optional:           true,
+				Computed:           true,
+				Default:            int64default.StaticInt64(0),
+				Description:        "Application status poll interval in seconds, `0` disables polling",
+				DeprecationMessage: "Use the status_report_poll block instead.",
+			},
+		},
+
+		Blocks: map[string]schema.Block{
+			"data_source_poll": schema.ListNestedBlock{
+				Description: "Polling of the git repository of the project, which runs `waypoint up` when it changes",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+							Description: "Whether Waypoint polls the git repository",
+						},
+						"interval": schema.StringAttribute{
+							Optional:    true,
+							Description: "Interval between polls, such as `30s` or `5m`. Required when `enabled` is true",
+							Validators: []validator.String{
+								durationValidator{},
+							},
+						},
+					},
+				},
+			},
+			"status_report_poll": schema.ListNestedBlock{
+				Description: "Polling of the status of the applications of the project",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+					listvalidator.ConflictsWith(path.MatchRoot("app_status_poll_seconds")),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"enabled": schema.BoolAttribute{
+							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(true),
+							Description: "Whether Waypoint polls the status of the applications",
+						},
+						"interval": schema.StringAttribute{
+							Optional:    true,
+							Description: "Interval between status reports, such as `30s` or `5m`. Required when `enabled` is true",
+							Validators: []validator.String{
+								durationValidator{},
+							},
+						},
+					},
+				},
+			},
+			"data_source_git": schema.ListNestedBlock{
 				Description: "Configuration of Git repository where waypoint.hcl file is stored",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"git_url": &schema.Schema{
-							Type:        schema.TypeString,
+				Validators: []validator.List{
+					listvalidator.IsRequired(),
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"git_url": schema.StringAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
 							Description: "Url of git repository storing the waypoint.hcl file",
 						},
-						"git_path": &schema.Schema{
-							Type:        schema.TypeString,
+						"git_path": schema.StringAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
 							Description: "Path in git repository when waypoint.hcl file is stored in a sub-directory",
 						},
-						"git_ref": &schema.Schema{
-							Type:        schema.TypeString,
+						"git_ref": schema.StringAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
 							Description: "Git repository ref containing waypoint.hcl file",
 						},
-						"ignore_changes_outside_path": &schema.Schema{
-							Type:        schema.TypeBool,
+						"ignore_changes_outside_path": schema.BoolAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     booldefault.StaticBool(false),
 							Description: "Whether Waypoint ignores changes outside path storing waypoint.hcl file",
 						},
-						"git_poll_interval_seconds": &schema.Schema{
-							Type:        schema.TypeInt,
-							Optional:    true,
-							Description: "Interval at which Waypoint should poll git repository for changes",
+						"git_poll_interval_seconds": schema.Int64Attribute{
+							Optional:           true,
+							Computed:           true,
+							Default:            int64default.StaticInt64(0),
+							Description:        "Interval at which Waypoint should poll git repository for changes, `0` disables polling",
+							DeprecationMessage: "Use the data_source_poll block instead.",
+							Validators: []validator.Int64{
+								int64validator.ConflictsWith(path.MatchRoot("data_source_poll")),
+							},
 						},
-						"file_change_signal": &schema.Schema{
-							Type:        schema.TypeString,
+						"file_change_signal": schema.StringAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
 							Description: "Indicates signal to be sent to any applications when their config files change.",
 						},
 					},
 				},
 			},
-			"remote_runners_enabled": &schema.Schema{
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Description: "Enable remote runners for project",
-			},
-			"git_auth_basic": &schema.Schema{
-				Type:        schema.TypeList,
-				Optional:    true,
+			"git_auth_basic": schema.ListNestedBlock{
 				Description: "Basic authentication details for Git consisting of `username` and `password`",
-				Sensitive:   true,
-				MaxItems:    1,
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"username": &schema.Schema{
-							Type:        schema.TypeString,
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"username": schema.StringAttribute{
 							Required:    true,
+							Sensitive:   true,
 							Description: "Git username",
 						},
-						"password": &schema.Schema{
-							Type:        schema.TypeString,
-							Required:    true,
+						"password": schema.StringAttribute{
+							Optional:    true,
 							Sensitive:   true,
-							Description: "Git password",
+							Description: "Git password. It is stored in the Terraform state, use `password_wo` or `password_file` to keep it out",
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative().AtParent().AtName("password_wo"),
+									path.MatchRelative().AtParent().AtName("password_file"),
+								),
+							},
+						},
+						"password_wo": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							WriteOnly:   true,
+							Description: "Git password, which is not stored in the Terraform state. Change `credentials_version` to update it. Requires Terraform 1.11 or later",
+						},
+						"password_file": schema.StringAttribute{
+							Optional:    true,
+							Description: "Path of a file holding the Git password, read when the project is applied. Change `credentials_version` to update the project after the file changes",
+						},
+						"credentials_version": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Version of the credentials, to change when `password_wo` or the file at `password_file` changes so that the project is updated",
+						},
+						"credentials_fingerprint": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: credentialsFingerprintDescription,
 						},
 					},
 				},
 			},
-			"git_auth_ssh": &schema.Schema{
-				Type:          schema.TypeList,
-				Optional:      true,
-				Sensitive:     true,
-				ConflictsWith: []string{"git_auth_basic"},
-				MaxItems:      1,
-				Description:   "SSH authentication details for Git",
-				Elem: &schema.Resource{
-					Schema: map[string]*schema.Schema{
-						"git_user": &schema.Schema{
-							Type:        schema.TypeString,
+			"git_auth_ssh": schema.ListNestedBlock{
+				Description: "SSH authentication details for Git",
+				Validators: []validator.List{
+					listvalidator.SizeAtMost(1),
+					listvalidator.ConflictsWith(path.MatchRoot("git_auth_basic")),
+				},
+				NestedObject: schema.NestedBlockObject{
+					Attributes: map[string]schema.Attribute{
+						"git_user": schema.StringAttribute{
 							Optional:    true,
+							Computed:    true,
+							Default:     stringdefault.StaticString(""),
+							Sensitive:   true,
 							Description: "Git user associated with private key",
 						},
-						"passphrase": &schema.Schema{
-							Type:        schema.TypeString,
+						"passphrase": schema.StringAttribute{
 							Optional:    true,
+							Computed:    true,
 							Sensitive:   true,
+							Default:     stringdefault.StaticString(""),
 							Description: "Passphrase to use with private key",
 						},
-						"ssh_private_key": &schema.Schema{
-							Type:        schema.TypeString,
-							Required:    true,
+						"passphrase_wo": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							WriteOnly:   true,
+							Description: "Passphrase to use with private key, which is not stored in the Terraform state. Requires Terraform 1.11 or later",
+							Validators: []validator.String{
+								stringvalidator.ConflictsWith(path.MatchRelative().AtParent().AtName("passphrase")),
+							},
+						},
+						"ssh_private_key": schema.StringAttribute{
+							Optional:    true,
+							Sensitive:   true,
+							Description: "Private key to authenticate to Git. It is stored in the Terraform state, use `ssh_private_key_wo` or `ssh_private_key_file` to keep it out",
+							Validators: []validator.String{
+								stringvalidator.ExactlyOneOf(
+									path.MatchRelative().AtParent().AtName("ssh_private_key_wo"),
+									path.MatchRelative().AtParent().AtName("ssh_private_key_file"),
+								),
+							},
+						},
+						"ssh_private_key_wo": schema.StringAttribute{
+							Optional:    true,
 							Sensitive:   true,
-							Description: "Private key to authenticate to Git",
+							WriteOnly:   true,
+							Description: "Private key to authenticate to Git, which is not stored in the Terraform state. Change `credentials_version` to update it. Requires Terraform 1.11 or later",
+						},
+						"ssh_private_key_file": schema.StringAttribute{
+							Optional:    true,
+							Description: "Path of a file holding the private key to authenticate to Git, read when the project is applied. Change `credentials_version` to update the project after the file changes",
+						},
+						"credentials_version": schema.Int64Attribute{
+							Optional:    true,
+							Description: "Version of the credentials, to change when `ssh_private_key_wo`, `passphrase_wo` or the file at `ssh_private_key_file` changes so that the project is updated",
+						},
+						"credentials_fingerprint": schema.StringAttribute{
+							Computed:    true,
+							Sensitive:   true,
+							Description: credentialsFingerprintDescription,
 						},
 					},
 				},
 			},
-			"app_status_poll_seconds": &schema.Schema{
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "Application status poll interval in seconds",
-			},
 		},
 	}
 }
 
-func resourceProjectCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+func (r *projectResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// The provider has not been configured yet during validation.
+	if req.ProviderData == nil {
+		return
+	}
 
-	wp := m.(*WaypointClient).conn
+	client, ok := req.ProviderData.(*WaypointClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *WaypointClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
 
-	projectConf := client.DefaultProjectConfig()
+	r.client = client
+}
 
-	// Git configuration for Waypoint project
-	var gitConfig *client.Git
+func (r *projectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data projectResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	validatePoll(path.Root("data_source_poll"), data.DataSourcePoll, &resp.Diagnostics)
+	validatePoll(path.Root("status_report_poll"), data.StatusReportPoll, &resp.Diagnostics)
 
-	authBasicList := d.Get("git_auth_basic").([]interface{})
-	authSshList := d.Get("git_auth_ssh").([]interface{})
+	if data.WaypointHcl.IsNull() || data.WaypointHcl.IsUnknown() || data.WaypointHclFormat.IsUnknown() {
+		return
+	}
+
+	format := gen.Hcl_HCL
+	if data.WaypointHclFormat.ValueString() == "json" {
+		format = gen.Hcl_JSON
+	}
 
-	dataSourceList := d.Get("data_source_git").([]interface{})
-	dataSourceSlice := dataSourceList[0].(map[string]interface{})
+	for _, d := range validateWaypointHcl(data.WaypointHcl.ValueString(), format, data.ProjectName) {
+		detail := d.Detail
+		if d.Subject != nil {
+			detail = fmt.Sprintf("%s: %s", d.Subject, d.Detail)
+		}
 
-	if len(authBasicList) > 0 {
-		var auth *client.GitAuthBasic
+		resp.Diagnostics.AddAttributeError(path.Root("waypoint_hcl"), d.Summary, detail)
+	}
+}
 
-		authBasicSlice := authBasicList[0].(map[string]interface{})
-		username := authBasicSlice["username"]
-		password := authBasicSlice["password"]
+// validatePoll checks an interval is set for each enabled polling block.
+func validatePoll(p path.Path, polls []projectPollModel, diags *diag.Diagnostics) {
+	for i, poll := range polls {
+		// enabled defaults to true when it is not set.
+		enabled := poll.Enabled.IsNull() || poll.Enabled.ValueBool()
 
-		auth = &client.GitAuthBasic{
-			Username: username.(string),
-			Password: password.(string),
+		if enabled && poll.Interval.IsNull() {
+			diags.AddAttributeError(
+				p.AtListIndex(i).AtName("interval"),
+				"Missing interval",
+				"interval must be set when polling is enabled.",
+			)
 		}
+	}
+}
+
+// ModifyPlan plans an update of the Git credentials when their fingerprint
+// on the server no longer matches the configured ones, which Terraform
+// cannot otherwise tell for write-only attributes and files.
+func (r *projectResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, config projectResourceModel
 
-		gitConfig = &client.Git{
-			Url:                      dataSourceSlice["git_url"].(string),
-			Path:                     dataSourceSlice["git_path"].(string),
-			IgnoreChangesOutsidePath: dataSourceSlice["ignore_changes_outside_path"].(bool),
-			Ref:                      dataSourceSlice["git_ref"].(string),
-			Auth:                     auth,
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// The fingerprint of a new project is computed once it is created,
+	// with the key it is then given.
+	if req.State.Raw.IsNull() {
+		return
+	}
+
+	var state projectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// prior is the fingerprint of the credentials on the server, and
+	// rotated tells whether the configured credentials visibly changed.
+	var p path.Path
+	var planned, prior types.String
+	var rotated bool
+	var credentials []types.String
+
+	switch {
+	case len(plan.GitAuthBasic) > 0:
+		basic := plan.GitAuthBasic[0]
+		p = path.Root("git_auth_basic").AtListIndex(0).AtName("credentials_fingerprint")
+		planned = basic.Fingerprint
+		credentials = []types.String{basic.Username, basic.Password, basic.PasswordFile, config.GitAuthBasic[0].PasswordWo}
+
+		if len(state.GitAuthBasic) > 0 {
+			s := state.GitAuthBasic[0]
+			prior = s.Fingerprint
+			rotated = !s.Username.Equal(basic.Username) || !s.Password.Equal(basic.Password) ||
+				!s.PasswordFile.Equal(basic.PasswordFile) || !s.CredentialsVersion.Equal(basic.CredentialsVersion)
 		}
-	} else if len(authSshList) > 0 {
-		var auth *client.GitAuthSsh
-		authSshSlice := authSshList[0].(map[string]interface{})
-		var passphrase interface{}
-		gitUser := authSshSlice["git_user"]
-		sshPrivateKey := authSshSlice["ssh_private_key"]
-		if authSshSlice["passphrase"] != nil {
-			passphrase = authSshSlice["passphrase"]
+	case len(plan.GitAuthSsh) > 0:
+		ssh := plan.GitAuthSsh[0]
+		p = path.Root("git_auth_ssh").AtListIndex(0).AtName("credentials_fingerprint")
+		planned = ssh.Fingerprint
+		credentials = []types.String{
+			ssh.GitUser, ssh.Passphrase, ssh.SshPrivateKey, ssh.SshPrivateKeyFile,
+			config.GitAuthSsh[0].PassphraseWo, config.GitAuthSsh[0].SshPrivateKeyWo,
 		}
 
-		auth = &client.GitAuthSsh{
-			User:          gitUser.(string),
-			PrivateKeyPem: []byte(sshPrivateKey.(string)),
-			Password:      passphrase.(string),
+		if len(state.GitAuthSsh) > 0 {
+			s := state.GitAuthSsh[0]
+			prior = s.Fingerprint
+			rotated = !s.GitUser.Equal(ssh.GitUser) || !s.Passphrase.Equal(ssh.Passphrase) || !s.SshPrivateKey.Equal(ssh.SshPrivateKey) ||
+				!s.SshPrivateKeyFile.Equal(ssh.SshPrivateKeyFile) || !s.CredentialsVersion.Equal(ssh.CredentialsVersion)
 		}
+	default:
+		return
+	}
 
-		gitConfig = &client.Git{
-			Url:                      dataSourceSlice["git_url"].(string),
-			Path:                     dataSourceSlice["git_path"].(string),
-			IgnoreChangesOutsidePath: dataSourceSlice["ignore_changes_outside_path"].(bool),
-			Ref:                      dataSourceSlice["git_ref"].(string),
-			Auth:                     auth,
+	for _, c := range credentials {
+		if c.IsUnknown() {
+			return
 		}
+	}
 
-	} else {
-		gitConfig = &client.Git{
-			Url:                      dataSourceSlice["git_url"].(string),
-			Path:                     dataSourceSlice["git_path"].(string),
-			IgnoreChangesOutsidePath: dataSourceSlice["ignore_changes_outside_path"].(bool),
-			Ref:                      dataSourceSlice["git_ref"].(string),
-			Auth:                     nil,
+	jobGit := &gen.Job_Git{}
+
+	resp.Diagnostics.Append(expandGitAuth(jobGit, &plan, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := gitAuthFingerprintKey(ctx, req.Private, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	fingerprint := gitAuthFingerprint(key, jobGit)
+	if planned.ValueString() == fingerprint {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, p, fingerprint)...)
+
+	// Fingerprints computed before they were keyed cannot be compared.
+	keyed := strings.HasPrefix(prior.ValueString(), gitAuthFingerprintPrefix)
+
+	if !rotated && keyed && prior.ValueString() != fingerprint {
+		resp.Diagnostics.AddAttributeWarning(
+			p,
+			"Git credentials changed out-of-band",
+			fmt.Sprintf(
+				"The Git credentials of the %s project on the server (%s) no longer match the configured ones (%s). "+
+					"They were changed outside of Terraform, or in a file or write-only value without changing credentials_version, "+
+					"and will be replaced by the configured ones.",
+				plan.ProjectName.ValueString(), prior.ValueString(), fingerprint,
+			),
+		)
+	}
+}
+
+func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data projectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only credentials are only set in the configuration.
+	var config projectResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := gitAuthFingerprintKey(ctx, nil, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upsert(ctx, &data, &config, types.MapNull(types.StringType), key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// write logs using the tflog package
+	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
+	// for more information
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data projectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := gitAuthFingerprintKey(ctx, req.Private, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.read(ctx, &data, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data projectResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Write-only credentials are only set in the configuration.
+	var config projectResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Variables removed from project_variables are deleted, and those set
+	// by other means are kept.
+	var priorVariables types.Map
+
+	resp.Diagnostics.Append(req.State.GetAttribute(ctx, path.Root("project_variables"), &priorVariables)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	key, diags := gitAuthFingerprintKey(ctx, req.Private, resp.Private)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upsert(ctx, &data, &config, priorVariables, key)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "updated a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data projectResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	name := data.ProjectName.ValueString()
+
+	unlock := lockProject(name)
+	defer unlock()
+
+	current, err := r.client.conn.GRPCClient().GetProject(ctx, &gen.GetProjectRequest{
+		Project: &gen.Ref_Project{Project: name},
+	})
+	if status.Code(err) == codes.NotFound {
+		return
+	}
+	if err != nil {
+		resp.Diagnostics.AddError(fmt.Sprintf("Error retrieving the %s project", name), err.Error())
+		return
+	}
+
+	vars := map[string]string{}
+	resp.Diagnostics.Append(data.ProjectVariables.ElementsAs(ctx, &vars, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Only the variables of project_variables are removed, the others are
+	// managed by waypoint_project_variable resources.
+	var variables []*gen.Variable
+	for _, variable := range current.GetProject().GetVariables() {
+		if _, managed := vars[variable.Name]; !managed {
+			variables = append(variables, variable)
 		}
+	}
 
+	// The Waypoint API cannot delete projects, so the project is detached
+	// from its repository instead.
+	project := &gen.Project{
+		Name: name,
+		DataSource: &gen.Job_DataSource{
+			Source: &gen.Job_DataSource_Git{Git: &gen.Job_Git{
+				Url:                      "RESOURCE DELETED",
+				Path:                     "RESOURCE DELETED",
+				IgnoreChangesOutsidePath: true,
+				Ref:                      "RESOURCE DELETED",
+			}},
+		},
+		DataSourcePoll:   &gen.Project_Poll{Enabled: false},
+		StatusReportPoll: &gen.Project_AppStatusPoll{Enabled: false},
+		Variables:        variables,
+	}
+
+	_, err = r.client.conn.GRPCClient().UpsertProject(ctx, &gen.UpsertProjectRequest{Project: project})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting the project", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+// upsert creates or updates the project described by data, then refreshes
+// data from the server. Write-only credentials are taken from config.
+// Variables on the server that are neither in project_variables nor in
+// priorVariables, its prior value, are kept as they are managed elsewhere.
+// fingerprintKey keys the fingerprint of the Git credentials.
+func (r *projectResource) upsert(ctx context.Context, data *projectResourceModel, config *projectResourceModel, priorVariables types.Map, fingerprintKey []byte) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	unlock := lockProject(data.ProjectName.ValueString())
+	defer unlock()
+
+	dataSourceGit := data.DataSourceGit[0]
+
+	// Git configuration for Waypoint project
+	jobGit := &gen.Job_Git{
+		Url:                      dataSourceGit.GitUrl.ValueString(),
+		Path:                     dataSourceGit.GitPath.ValueString(),
+		IgnoreChangesOutsidePath: dataSourceGit.IgnoreChangesOutsidePath.ValueBool(),
+		Ref:                      dataSourceGit.GitRef.ValueString(),
+	}
+
+	diags.Append(expandGitAuth(jobGit, data, config)...)
+	if diags.HasError() {
+		return diags
+	}
+
+	// The project is sent with the gRPC client rather than
+	// client.UpsertProject, which can only enable polling together with an
+	// interval.
+	project := &gen.Project{
+		Name:          data.ProjectName.ValueString(),
+		RemoteEnabled: data.RemoteRunnersEnabled.ValueBool(),
+		DataSource: &gen.Job_DataSource{
+			Source: &gen.Job_DataSource_Git{Git: jobGit},
+		},
+		DataSourcePoll:   expandDataSourcePoll(data),
+		FileChangeSignal: dataSourceGit.FileChangeSignal.ValueString(),
+		StatusReportPoll: expandStatusReportPoll(data),
+	}
+
+	// The format is always sent, as it is read back even without
+	// waypoint_hcl.
+	project.WaypointHclFormat = expandWaypointHclFormat(data.WaypointHclFormat.ValueString())
+	if !data.WaypointHcl.IsNull() {
+		project.WaypointHcl = []byte(data.WaypointHcl.ValueString())
 	}
 
 	// Project variables configuration
 	var variableList []*gen.Variable
-	varsList := d.Get("project_variables").(map[string]interface{})
+	vars := map[string]string{}
+	priorVars := map[string]string{}
 
-	for key, value := range varsList {
+	diags.Append(data.ProjectVariables.ElementsAs(ctx, &vars, false)...)
+	diags.Append(priorVariables.ElementsAs(ctx, &priorVars, false)...)
+	if diags.HasError() {
+		return diags
+	}
 
-		projectVariable := client.SetVariable()
-		projectVariable.Name = key
-		projectVariable.Value = &gen.Variable_Str{Str: value.(string)}
-		variableList = append(variableList, &projectVariable)
+	current, err := r.client.conn.GRPCClient().GetProject(ctx, &gen.GetProjectRequest{
+		Project: &gen.Ref_Project{Project: project.Name},
+	})
+	if err != nil && status.Code(err) != codes.NotFound {
+		diags.AddError(fmt.Sprintf("Error retrieving the %s project", project.Name), err.Error())
+		return diags
 	}
 
-	// Project config for request
-	projectName := d.Get("project_name").(string)
-	d.SetId(projectName)
-	projectConf.Name = d.Get("project_name").(string)
-	projectConf.RemoteRunnersEnabled = d.Get("remote_runners_enabled").(bool)
+	for _, variable := range current.GetProject().GetVariables() {
+		_, managed := vars[variable.Name]
+		_, removed := priorVars[variable.Name]
+
+		if !managed && !removed {
+			variableList = append(variableList, variable)
+		}
 
-	if appStatusPollSeconds, ok := d.Get("app_status_poll_seconds").(int); ok {
-		projectConf.StatusReportPoll = time.Duration(appStatusPollSeconds) * time.Second
+		// A variable added to project_variables that is already on the
+		// server is likely managed by a waypoint_project_variable resource,
+		// and both would keep overwriting it.
+		if managed && !removed {
+			diags.AddAttributeError(
+				path.Root("project_variables").AtMapKey(variable.Name),
+				"Variable already exists",
+				fmt.Sprintf(
+					"The %s project already has a variable named %s. "+
+						"It may be managed by a waypoint_project_variable resource, which cannot manage the same variable as the project_variables of a waypoint_project resource. "+
+						"Remove it from project_variables, or remove the other resource from the state first.",
+					project.Name, variable.Name,
+				),
+			)
+		}
 	}
 
-	if fileChangeSignal, ok := dataSourceSlice["file_change_signal"].(string); ok {
-		projectConf.FileChangeSignal = fileChangeSignal
+	if diags.HasError() {
+		return diags
 	}
 
-	if dataSourcePollInterval, ok := dataSourceSlice["git_poll_interval_seconds"].(int); ok {
-		projectConf.GitPollInterval = time.Duration(dataSourcePollInterval) * time.Second
+	for key, value := range vars {
+		projectVariable := client.SetVariable()
+		projectVariable.Name = key
+		projectVariable.Value = &gen.Variable_Str{Str: value}
+		variableList = append(variableList, &projectVariable)
 	}
 
-	_, err := wp.UpsertProject(context.TODO(), projectConf, gitConfig, variableList)
+	project.Variables = variableList
 
+	_, err = r.client.conn.GRPCClient().UpsertProject(ctx, &gen.UpsertProjectRequest{Project: project})
 	if err != nil {
-		return diag.FromErr(err)
+		diags.AddError("Error upserting the project", err.Error())
+		return diags
 	}
 
-	// write logs using the tflog package
-	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
-	// for more information
-	tflog.Trace(ctx, "created a resource")
+	diags.Append(r.read(ctx, data, fingerprintKey)...)
+
+	return diags
+}
+
+// projectLocks serializes the changes made to a project by the resources of
+// this provider, which each send the whole project to the server.
+var projectLocks sync.Map
+
+// lockProject locks the named project and returns the function unlocking it.
+func lockProject(name string) func() {
+	mu, _ := projectLocks.LoadOrStore(name, &sync.Mutex{})
+	mu.(*sync.Mutex).Lock()
+
+	return mu.(*sync.Mutex).Unlock
+}
+
+// expandGitAuth sets the authentication of jobGit from the git_auth_basic
+// or git_auth_ssh block of data, with write-only credentials taken from
+// config and files read from disk.
+func expandGitAuth(jobGit *gen.Job_Git, data *projectResourceModel, config *projectResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if len(data.GitAuthBasic) > 0 {
+		password, err := credential(data.GitAuthBasic[0].Password, config.GitAuthBasic[0].PasswordWo, data.GitAuthBasic[0].PasswordFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("git_auth_basic").AtListIndex(0).AtName("password_file"), "Unable to read the Git password", err.Error())
+			return diags
+		}
+
+		jobGit.Auth = &gen.Job_Git_Basic_{Basic: &gen.Job_Git_Basic{
+			Username: data.GitAuthBasic[0].Username.ValueString(),
+			Password: password,
+		}}
+	} else if len(data.GitAuthSsh) > 0 {
+		privateKey, err := credential(data.GitAuthSsh[0].SshPrivateKey, config.GitAuthSsh[0].SshPrivateKeyWo, data.GitAuthSsh[0].SshPrivateKeyFile)
+		if err != nil {
+			diags.AddAttributeError(path.Root("git_auth_ssh").AtListIndex(0).AtName("ssh_private_key_file"), "Unable to read the Git private key", err.Error())
+			return diags
+		}
+
+		passphrase := data.GitAuthSsh[0].Passphrase.ValueString()
+		if !config.GitAuthSsh[0].PassphraseWo.IsNull() {
+			passphrase = config.GitAuthSsh[0].PassphraseWo.ValueString()
+		}
 
-	return resourceProjectRead(ctx, d, m)
+		jobGit.Auth = &gen.Job_Git_Ssh{Ssh: &gen.Job_Git_SSH{
+			User:          data.GitAuthSsh[0].GitUser.ValueString(),
+			PrivateKeyPem: []byte(privateKey),
+			Password:      passphrase,
+		}}
+	}
+
+	return diags
 }
 
-func resourceProjectRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
-	client := m.(*WaypointClient).conn
+// read refreshes data with the project stored on the server.
+func (r *projectResource) read(ctx context.Context, data *projectResourceModel, fingerprintKey []byte) diag.Diagnostics {
+	var diags diag.Diagnostics
 
-	projectName := d.Get("project_name").(string)
-	project, err := client.GetProject(context.TODO(), projectName)
+	projectName := data.ProjectName.ValueString()
+	project, err := r.client.conn.GetProject(ctx, projectName)
 	if err != nil {
-		return diag.Errorf("Error retrieving the %s project", projectName)
+		diags.AddError(fmt.Sprintf("Error retrieving the %s project", projectName), err.Error())
+		return diags
 	}
 
-	d.SetId(project.Name)
+	data.Id = types.StringValue(project.Name)
+	data.RemoteRunnersEnabled = types.BoolValue(project.RemoteEnabled)
 
-	d.Set("remote_runners_enabled", project.RemoteEnabled)
+	// Only the variables in project_variables are read back, as others may
+	// be managed by waypoint_project_variable resources. An unset map is
+	// kept null.
+	if !data.ProjectVariables.IsNull() {
+		managed := map[string]string{}
 
-	applications := flattenApplications(project.Applications)
-	d.Set("applications", applications)
+		diags.Append(data.ProjectVariables.ElementsAs(ctx, &managed, false)...)
+		if diags.HasError() {
+			return diags
+		}
 
-	variables := flattenVariables(project.Variables)
-	d.Set("project_variables", variables)
+		variables := map[string]string{}
+		for _, variable := range project.Variables {
+			_, ok := managed[variable.Name]
+			if str, isStr := variable.Value.(*gen.Variable_Str); ok && isStr {
+				variables[variable.Name] = str.Str
+			}
+		}
 
-	dataSourceGitSlice := map[string]interface{}{}
-	dataSourceGitSlice["git_url"] = project.DataSource.GetGit().Url
-	dataSourceGitSlice["git_path"] = project.DataSource.GetGit().Path
-	dataSourceGitSlice["git_ref"] = project.DataSource.GetGit().Ref
-	dataSourceGitSlice["file_change_signal"] = project.FileChangeSignal
+		projectVariables, d := types.MapValueFrom(ctx, types.StringType, variables)
+		diags.Append(d...)
+		data.ProjectVariables = projectVariables
+	}
 
-	dpi, _ := time.ParseDuration(project.DataSourcePoll.Interval)
-	dataSourceGitSlice["git_poll_interval_seconds"] = dpi / time.Second
-	d.Set("data_source_git", []interface{}{dataSourceGitSlice})
+	git := project.DataSource.GetGit()
+	data.DataSourceGit = []projectDataSourceGitModel{
+		{
+			GitUrl:                   types.StringValue(git.GetUrl()),
+			GitPath:                  types.StringValue(git.GetPath()),
+			GitRef:                   types.StringValue(git.GetRef()),
+			IgnoreChangesOutsidePath: types.BoolValue(git.GetIgnoreChangesOutsidePath()),
+			GitPollIntervalSeconds:   types.Int64Value(0),
+			FileChangeSignal:         types.StringValue(project.FileChangeSignal),
+		},
+	}
 
-	gitAuthBasicSlice := map[string]interface{}{}
-	gitAuthSshSlice := map[string]interface{}{}
+	if len(data.DataSourcePoll) > 0 {
+		data.DataSourcePoll = []projectPollModel{
+			flattenPoll(data.DataSourcePoll[0], project.DataSourcePoll.GetEnabled(), project.DataSourcePoll.GetInterval()),
+		}
+	} else {
+		data.DataSourcePoll = []projectPollModel{}
 
-	gitAuth := project.DataSource.Source.(*gen.Job_DataSource_Git).Git.Auth
-	switch gitAuth.(type) {
+		if project.DataSourcePoll.GetEnabled() {
+			data.DataSourceGit[0].GitPollIntervalSeconds = types.Int64Value(durationSeconds(project.DataSourcePoll.GetInterval()))
+		}
+	}
+
+	priorBasic, priorSsh := data.GitAuthBasic, data.GitAuthSsh
+	data.GitAuthBasic = []projectGitAuthBasicModel{}
+	data.GitAuthSsh = []projectGitAuthSshModel{}
+
+	// Secrets are only read back into the attributes that store them in the
+	// state. Those given as write-only attributes or files are left out.
+	// Servers that redact the secrets keep the prior fingerprint, as they
+	// cannot be compared.
+	switch auth := git.GetAuth().(type) {
 	case *gen.Job_Git_Basic_:
-		gitAuthBasicSlice["username"] = gitAuth.(*gen.Job_Git_Basic_).Basic.Username
-		gitAuthBasicSlice["password"] = gitAuth.(*gen.Job_Git_Basic_).Basic.Password
-		d.Set("git_auth_basic", []interface{}{gitAuthBasicSlice})
+		basic := projectGitAuthBasicModel{
+			Username:           types.StringValue(auth.Basic.Username),
+			Password:           types.StringValue(auth.Basic.Password),
+			PasswordWo:         types.StringNull(),
+			PasswordFile:       types.StringNull(),
+			CredentialsVersion: types.Int64Null(),
+			Fingerprint:        types.StringValue(gitAuthFingerprint(fingerprintKey, git)),
+		}
+
+		if len(priorBasic) > 0 {
+			basic.PasswordFile = priorBasic[0].PasswordFile
+			basic.CredentialsVersion = priorBasic[0].CredentialsVersion
+
+			if auth.Basic.Password == "" {
+				basic.Fingerprint = priorBasic[0].Fingerprint
+			}
+
+			if priorBasic[0].Password.IsNull() {
+				basic.Password = types.StringNull()
+			}
+		}
+
+		data.GitAuthBasic = append(data.GitAuthBasic, basic)
 	case *gen.Job_Git_Ssh:
-		gitAuthSshSlice["git_user"] = gitAuth.(*gen.Job_Git_Ssh).Ssh.User
-		gitAuthSshSlice["passphrase"] = gitAuth.(*gen.Job_Git_Ssh).Ssh.Password
-		gitAuthSshSlice["ssh_private_key"] = string(gitAuth.(*gen.Job_Git_Ssh).Ssh.PrivateKeyPem)
-		d.Set("git_auth_ssh", []interface{}{gitAuthSshSlice})
+		ssh := projectGitAuthSshModel{
+			GitUser:            types.StringValue(auth.Ssh.User),
+			Passphrase:         types.StringValue(auth.Ssh.Password),
+			PassphraseWo:       types.StringNull(),
+			SshPrivateKey:      types.StringValue(string(auth.Ssh.PrivateKeyPem)),
+			SshPrivateKeyWo:    types.StringNull(),
+			SshPrivateKeyFile:  types.StringNull(),
+			CredentialsVersion: types.Int64Null(),
+			Fingerprint:        types.StringValue(gitAuthFingerprint(fingerprintKey, git)),
+		}
+
+		if len(priorSsh) > 0 {
+			ssh.SshPrivateKeyFile = priorSsh[0].SshPrivateKeyFile
+			ssh.CredentialsVersion = priorSsh[0].CredentialsVersion
+
+			if len(auth.Ssh.PrivateKeyPem) == 0 {
+				ssh.Fingerprint = priorSsh[0].Fingerprint
+			}
+
+			if priorSsh[0].SshPrivateKey.IsNull() {
+				ssh.SshPrivateKey = types.StringNull()
+			}
+
+			// The passphrase defaults to "", which is kept when it is
+			// given in passphrase_wo instead.
+			if priorSsh[0].Passphrase.ValueString() == "" {
+				ssh.Passphrase = types.StringValue("")
+			}
+		}
+
+		data.GitAuthSsh = append(data.GitAuthSsh, ssh)
 	}
 
-	if project.StatusReportPoll != nil {
-		asps := project.StatusReportPoll.Interval
-		aspsParse, _ := time.ParseDuration(asps)
-		d.Set("app_status_poll_seconds", aspsParse/time.Second)
+	data.WaypointHclFormat = types.StringValue(flattenWaypointHclFormat(project.WaypointHclFormat))
+	data.WaypointHcl = flattenWaypointHcl(data.WaypointHcl, string(project.WaypointHcl), project.WaypointHclFormat)
+
+	data.AppStatusPollSeconds = types.Int64Value(0)
+
+	if len(data.StatusReportPoll) > 0 {
+		data.StatusReportPoll = []projectPollModel{
+			flattenPoll(data.StatusReportPoll[0], project.StatusReportPoll.GetEnabled(), project.StatusReportPoll.GetInterval()),
+		}
+	} else {
+		data.StatusReportPoll = []projectPollModel{}
+
+		// An interval is reported for disabled polling too, so it is only
+		// read into the deprecated attribute when polling is enabled.
+		if project.StatusReportPoll.GetEnabled() {
+			data.AppStatusPollSeconds = types.Int64Value(durationSeconds(project.StatusReportPoll.GetInterval()))
+		}
 	}
 
-	return nil
+	return diags
 }
 
-func resourceProjectDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
+const credentialsFingerprintDescription = "Fingerprint of the credentials stored on the server, an HMAC-SHA256 keyed with a random key " +
+	"kept in the private state of the project, so it cannot be used to guess the credentials from plans. " +
+	"The project is updated when it no longer matches the configured credentials, " +
+	"whether they were changed outside of Terraform or rotated in a file or variable shared by several projects"
 
+// gitAuthFingerprintPrefix prefixes the fingerprints of Git credentials.
+const gitAuthFingerprintPrefix = "hmac-sha256:"
+
+// gitAuthFingerprintKeyName is the private state key holding the key of the
+// fingerprint of the Git credentials of a project.
+const gitAuthFingerprintKeyName = "git_auth_fingerprint_key"
+
+// privateState is implemented by the private state of resource requests and
+// responses.
+type privateState interface {
+	GetKey(ctx context.Context, key string) ([]byte, diag.Diagnostics)
+	SetKey(ctx context.Context, key string, value []byte) diag.Diagnostics
+}
+
+// gitAuthFingerprintKey returns the key of the fingerprint of the Git
+// credentials held in prior. A random key is created when there is none,
+// and stored in next.
+func gitAuthFingerprintKey(ctx context.Context, prior privateState, next privateState) ([]byte, diag.Diagnostics) {
 	var diags diag.Diagnostics
-	wp := m.(*WaypointClient).conn
 
-	gc := client.Git{
-		Url:                      "RESOURCE DELETED",
-		Path:                     "RESOURCE DELETED",
-		IgnoreChangesOutsidePath: true,
-		Ref:                      "RESOURCE DELETED",
-		Auth:                     nil,
+	if prior != nil {
+		stored, d := prior.GetKey(ctx, gitAuthFingerprintKeyName)
+		diags.Append(d...)
+
+		var encoded string
+		if len(stored) > 0 && json.Unmarshal(stored, &encoded) == nil {
+			if key, err := hex.DecodeString(encoded); err == nil && len(key) > 0 {
+				return key, diags
+			}
+		}
 	}
 
-	projectName := d.Get("project_name").(string)
-	d.SetId(projectName)
-	projectConf := client.DefaultProjectConfig()
-	projectConf.Name = d.Get("project_name").(string)
+	key := make([]byte, 32)
+	if _, err := rand.Read(key); err != nil {
+		diags.AddError("Unable to create the key of the Git credentials fingerprint", err.Error())
+		return nil, diags
+	}
 
-	_, err := wp.UpsertProject(context.TODO(), projectConf, &gc, nil)
+	stored, _ := json.Marshal(hex.EncodeToString(key))
+	diags.Append(next.SetKey(ctx, gitAuthFingerprintKeyName, stored)...)
 
-	if err != nil {
-		return diag.FromErr(err)
+	return key, diags
+}
+
+// gitAuthFingerprint returns a fingerprint of the authentication of git,
+// keyed with key, which tells credentials apart without revealing them.
+func gitAuthFingerprint(key []byte, git *gen.Job_Git) string {
+	var parts []string
+
+	switch auth := git.GetAuth().(type) {
+	case *gen.Job_Git_Basic_:
+		parts = []string{"basic", auth.Basic.Username, auth.Basic.Password}
+	case *gen.Job_Git_Ssh:
+		parts = []string{"ssh", auth.Ssh.User, string(auth.Ssh.PrivateKeyPem), auth.Ssh.Password}
+	default:
+		return ""
 	}
 
-	// write logs using the tflog package
-	// see https://pkg.go.dev/github.com/hashicorp/terraform-plugin-log/tflog
-	// for more information
-	tflog.Trace(ctx, "created a resource")
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(strings.Join(parts, "\x00")))
+
+	return gitAuthFingerprintPrefix + hex.EncodeToString(mac.Sum(nil))
+}
+
+// credential returns the secret held by value, writeOnly or the file at
+// filePath, whichever is set.
+func credential(value, writeOnly, filePath types.String) (string, error) {
+	switch {
+	case !writeOnly.IsNull():
+		return writeOnly.ValueString(), nil
+	case !filePath.IsNull():
+		b, err := os.ReadFile(filePath.ValueString())
+		if err != nil {
+			return "", err
+		}
+
+		return strings.TrimRight(string(b), "\r\n"), nil
+	}
+
+	return value.ValueString(), nil
+}
+
+var waypointHclFormats = []string{"hcl", "json"}
+
+func expandWaypointHclFormat(format string) gen.Hcl_Format {
+	if format == "json" {
+		return gen.Hcl_JSON
+	}
+
+	return gen.Hcl_HCL
+}
+
+func flattenWaypointHclFormat(format gen.Hcl_Format) string {
+	if format == gen.Hcl_JSON {
+		return "json"
+	}
+
+	return "hcl"
+}
+
+// flattenWaypointHcl returns the waypoint.hcl reported by the server, keeping
+// prior when it only differs in formatting so that drift is shown only for
+// changes to the configuration.
+func flattenWaypointHcl(prior types.String, waypointHcl string, format gen.Hcl_Format) types.String {
+	if waypointHcl == "" && prior.IsNull() {
+		return types.StringNull()
+	}
+
+	equivalent := equivalentHcl
+	if format == gen.Hcl_JSON {
+		equivalent = equivalentJson
+	}
+
+	if !prior.IsNull() && equivalent(prior.ValueString(), waypointHcl) {
+		return prior
+	}
+
+	return types.StringValue(waypointHcl)
+}
+
+// validateWaypointHcl checks src parses as a waypoint.hcl file in the given
+// syntax, that its applications have distinct names and that its project, if
+// set, is projectName.
+func validateWaypointHcl(src string, format gen.Hcl_Format, projectName types.String) hcl.Diagnostics {
+	file, diags := parseWaypointHcl(src, format)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	content, _, diags := file.Body.PartialContent(&hcl.BodySchema{
+		Attributes: []hcl.AttributeSchema{{Name: "project"}},
+		Blocks:     waypointHclSchema.Blocks,
+	})
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if attribute, ok := content.Attributes["project"]; ok && !projectName.IsNull() && !projectName.IsUnknown() {
+		var project string
+
+		value, d := attribute.Expr.Value(nil)
+		if !d.HasErrors() && value.Type() == cty.String && value.IsKnown() && !value.IsNull() {
+			project = value.AsString()
+		}
+
+		if project != projectName.ValueString() {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Project name mismatch",
+				Detail:   fmt.Sprintf("project must be %q, the project_name of this project.", projectName.ValueString()),
+				Subject:  attribute.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	apps := map[string]hcl.Range{}
+
+	for _, block := range content.Blocks {
+		name := block.Labels[0]
+
+		if declared, ok := apps[name]; ok {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Duplicate app",
+				Detail:   fmt.Sprintf("app %q is already declared at %s.", name, declared),
+				Subject:  block.LabelRanges[0].Ptr(),
+			})
+			continue
+		}
+
+		apps[name] = block.DefRange
+	}
 
 	return diags
 }
+
+// expandDataSourcePoll returns the git polling settings of the project, from
+// the data_source_poll block or the deprecated git_poll_interval_seconds.
+func expandDataSourcePoll(data *projectResourceModel) *gen.Project_Poll {
+	if len(data.DataSourcePoll) > 0 {
+		poll := data.DataSourcePoll[0]
+
+		return &gen.Project_Poll{
+			Enabled:  poll.Enabled.ValueBool(),
+			Interval: poll.Interval.ValueString(),
+		}
+	}
+
+	interval := time.Duration(data.DataSourceGit[0].GitPollIntervalSeconds.ValueInt64()) * time.Second
+
+	return &gen.Project_Poll{
+		Enabled:  interval > 0,
+		Interval: interval.String(),
+	}
+}
+
+// expandStatusReportPoll returns the application status polling settings of
+// the project, from the status_report_poll block or the deprecated
+// app_status_poll_seconds.
+func expandStatusReportPoll(data *projectResourceModel) *gen.Project_AppStatusPoll {
+	if len(data.StatusReportPoll) > 0 {
+		poll := data.StatusReportPoll[0]
+
+		return &gen.Project_AppStatusPoll{
+			Enabled:  poll.Enabled.ValueBool(),
+			Interval: poll.Interval.ValueString(),
+		}
+	}
+
+	interval := time.Duration(data.AppStatusPollSeconds.ValueInt64()) * time.Second
+
+	return &gen.Project_AppStatusPoll{
+		Enabled:  interval > 0,
+		Interval: interval.String(),
+	}
+}
+
+// flattenPoll returns the polling block for the settings reported by the
+// server. An interval equal to the one in prior, such as "1m" and "60s", is
+// kept as written.
+func flattenPoll(prior projectPollModel, enabled bool, interval string) projectPollModel {
+	poll := projectPollModel{
+		Enabled:  types.BoolValue(enabled),
+		Interval: types.StringValue(interval),
+	}
+
+	switch {
+	case interval == "" && prior.Interval.IsNull():
+		poll.Interval = types.StringNull()
+	case equivalentDuration(prior.Interval.ValueString(), interval):
+		poll.Interval = prior.Interval
+	}
+
+	return poll
+}
+
+// equivalentDuration reports whether a and b are the same duration, such as
+// "1m" and "1m0s".
+func equivalentDuration(a, b string) bool {
+	aDuration, err := time.ParseDuration(a)
+	if err != nil {
+		return false
+	}
+
+	bDuration, err := time.ParseDuration(b)
+	if err != nil {
+		return false
+	}
+
+	return aDuration == bDuration
+}
+
+// durationValidator checks a string is a duration such as "30s".
+type durationValidator struct{}
+
+var _ validator.String = durationValidator{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "value must be a duration such as \"30s\" or \"5m\""
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "value must be a duration such as `30s` or `5m`"
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+
+	if _, err := time.ParseDuration(req.ConfigValue.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid duration", err.Error())
+	}
+}
+
+// durationSeconds converts a duration string reported by the server, such as
+// "1m30s", to whole seconds. Blank or invalid durations are treated as zero.
+func durationSeconds(duration string) int64 {
+	d, _ := time.ParseDuration(duration)
+
+	return int64(d / time.Second)
+}
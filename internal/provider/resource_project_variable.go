@@ -0,0 +1,419 @@
+package waypoint
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// projectVariableResource manages a single variable of a project, so that
+// variables can be owned by different configurations. Variables are stored
+// in the project, which has no RPC of its own for them, so each change
+// sends the whole project back to the server.
+type projectVariableResource struct {
+	client *WaypointClient
+}
+
+type projectVariableResourceModel struct {
+	Id          types.String `tfsdk:"id"`
+	ProjectName types.String `tfsdk:"project_name"`
+	Name        types.String `tfsdk:"name"`
+	Type        types.String `tfsdk:"type"`
+	Value       types.String `tfsdk:"value"`
+	Sensitive   types.Bool   `tfsdk:"sensitive"`
+}
+
+var (
+	_ resource.Resource                   = &projectVariableResource{}
+	_ resource.ResourceWithConfigure      = &projectVariableResource{}
+	_ resource.ResourceWithValidateConfig = &projectVariableResource{}
+	_ resource.ResourceWithImportState    = &projectVariableResource{}
+)
+
+func newProjectVariableResource() resource.Resource {
+	return &projectVariableResource{}
+}
+
+// projectVariableTypes are the types of values a variable can hold.
+var projectVariableTypes = []string{"string", "bool", "number", "hcl"}
+
+func (r *projectVariableResource) Metadata(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_variable"
+}
+
+func (r *projectVariableResource) Schema(ctx context.Context, req resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		// Variables of the Waypoint API this provider is built against
+		// have no workspace, they apply to every workspace of the project.
+		Description: "A variable of a Waypoint project, managed separately from the `waypoint_project` resource. " +
+			"The variable applies to every workspace of the project, as Waypoint does not scope project variables to workspaces. " +
+			"A variable cannot be managed both by this resource and in the `project_variables` of a `waypoint_project` resource.",
+
+		Attributes: map[string]schema.Attribute{
+			"id": schema.StringAttribute{
+				Computed:    true,
+				Description: "The `project_name` and `name` of the variable, separated by a slash, with which it can be imported",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"project_name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the Waypoint project",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"name": schema.StringAttribute{
+				Required:    true,
+				Description: "The name of the variable",
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"type": schema.StringAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("string"),
+				Description: "The type of the value, one of `string`, `bool`, `number` or `hcl`, for values of complex types given as an HCL expression. Defaults to `string`",
+				Validators: []validator.String{
+					stringvalidator.OneOf(projectVariableTypes...),
+				},
+			},
+			"value": schema.StringAttribute{
+				Required:    true,
+				Sensitive:   true,
+				Description: "The value of the variable",
+			},
+			"sensitive": schema.BoolAttribute{
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				Description: "Whether Waypoint hides the value of the variable in its output. The value of a sensitive variable is not read back from the server",
+			},
+		},
+	}
+}
+
+func (r *projectVariableResource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// The provider has not been configured yet during validation.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*WaypointClient)
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Resource Configure Type",
+			fmt.Sprintf("Expected *WaypointClient, got: %T.", req.ProviderData),
+		)
+		return
+	}
+
+	r.client = client
+}
+
+func (r *projectVariableResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var data projectVariableResourceModel
+
+	resp.Diagnostics.Append(req.Config.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	if data.Type.IsUnknown() || data.Value.IsNull() || data.Value.IsUnknown() {
+		return
+	}
+
+	typ := data.Type.ValueString()
+	if data.Type.IsNull() {
+		typ = "string"
+	}
+
+	// The value is sensitive, so it is left out of the error.
+	if err := expandVariableValue(&gen.Variable{}, typ, data.Value.ValueString()); err != nil {
+		resp.Diagnostics.AddAttributeError(path.Root("value"), "Invalid variable value", err.Error())
+	}
+}
+
+func (r *projectVariableResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var data projectVariableResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upsert(ctx, &data, true)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	tflog.Trace(ctx, "created a resource")
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectVariableResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	var data projectVariableResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	project, diags := r.getProject(ctx, data.ProjectName.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	variable := findVariable(project, data.Name.ValueString())
+	if variable == nil {
+		tflog.Warn(ctx, "variable not found, removing it from the state", map[string]interface{}{
+			"project": data.ProjectName.ValueString(),
+			"name":    data.Name.ValueString(),
+		})
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	flattenProjectVariable(&data, variable)
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectVariableResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	var data projectVariableResourceModel
+
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(r.upsert(ctx, &data, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.Set(ctx, &data)...)
+}
+
+func (r *projectVariableResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	var data projectVariableResourceModel
+
+	resp.Diagnostics.Append(req.State.Get(ctx, &data)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	unlock := lockProject(data.ProjectName.ValueString())
+	defer unlock()
+
+	project, diags := r.getProject(ctx, data.ProjectName.ValueString())
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() || project == nil {
+		return
+	}
+
+	var variables []*gen.Variable
+	for _, variable := range project.Variables {
+		if variable.Name != data.Name.ValueString() {
+			variables = append(variables, variable)
+		}
+	}
+
+	project.Variables = variables
+
+	_, err := r.client.conn.GRPCClient().UpsertProject(ctx, &gen.UpsertProjectRequest{Project: project})
+	if err != nil {
+		resp.Diagnostics.AddError("Error deleting the variable", err.Error())
+		return
+	}
+
+	tflog.Trace(ctx, "deleted a resource")
+}
+
+func (r *projectVariableResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	project, name, ok := strings.Cut(req.ID, "/")
+	if !ok || project == "" || name == "" {
+		resp.Diagnostics.AddError(
+			"Invalid import ID",
+			fmt.Sprintf("Expected <project_name>/<name>, got: %q.", req.ID),
+		)
+		return
+	}
+
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("project_name"), project)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("name"), name)...)
+}
+
+// upsert sets the variable described by data on its project. When create is
+// true, a variable of the same name must not exist yet.
+func (r *projectVariableResource) upsert(ctx context.Context, data *projectVariableResourceModel, create bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	projectName, name := data.ProjectName.ValueString(), data.Name.ValueString()
+
+	unlock := lockProject(projectName)
+	defer unlock()
+
+	project, d := r.getProject(ctx, projectName)
+	diags.Append(d...)
+	if diags.HasError() {
+		return diags
+	}
+
+	if project == nil {
+		diags.AddAttributeError(path.Root("project_name"), "Project not found", fmt.Sprintf("The %s project does not exist.", projectName))
+		return diags
+	}
+
+	if create && findVariable(project, name) != nil {
+		diags.AddAttributeError(
+			path.Root("name"),
+			"Variable already exists",
+			fmt.Sprintf(
+				"The %s project already has a variable named %s. "+
+					"It may be set in the project_variables of a waypoint_project resource, which cannot manage the same variable as a waypoint_project_variable resource. "+
+					"Remove it from there, or import it with the ID %s/%s.",
+				projectName, name, projectName, name,
+			),
+		)
+		return diags
+	}
+
+	variable := &gen.Variable{
+		Name:      name,
+		Sensitive: data.Sensitive.ValueBool(),
+	}
+
+	if err := expandVariableValue(variable, data.Type.ValueString(), data.Value.ValueString()); err != nil {
+		diags.AddAttributeError(path.Root("value"), "Invalid variable value", err.Error())
+		return diags
+	}
+
+	variables := []*gen.Variable{variable}
+	for _, v := range project.Variables {
+		if v.Name != name {
+			variables = append(variables, v)
+		}
+	}
+
+	project.Variables = variables
+
+	_, err := r.client.conn.GRPCClient().UpsertProject(ctx, &gen.UpsertProjectRequest{Project: project})
+	if err != nil {
+		diags.AddError("Error upserting the variable", err.Error())
+		return diags
+	}
+
+	data.Id = types.StringValue(projectName + "/" + name)
+
+	return diags
+}
+
+// getProject returns the named project, or nil when it does not exist.
+func (r *projectVariableResource) getProject(ctx context.Context, name string) (*gen.Project, diag.Diagnostics) {
+	var diags diag.Diagnostics
+
+	resp, err := r.client.conn.GRPCClient().GetProject(ctx, &gen.GetProjectRequest{
+		Project: &gen.Ref_Project{Project: name},
+	})
+	if status.Code(err) == codes.NotFound {
+		return nil, diags
+	}
+	if err != nil {
+		diags.AddError(fmt.Sprintf("Error retrieving the %s project", name), err.Error())
+		return nil, diags
+	}
+
+	return resp.Project, diags
+}
+
+// findVariable returns the named variable of project, or nil.
+func findVariable(project *gen.Project, name string) *gen.Variable {
+	for _, variable := range project.GetVariables() {
+		if variable.Name == name {
+			return variable
+		}
+	}
+
+	return nil
+}
+
+// expandVariableValue sets the value of variable from its type and string
+// representation. Errors do not quote the value, which may be sensitive.
+func expandVariableValue(variable *gen.Variable, typ, value string) error {
+	switch typ {
+	case "bool":
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return fmt.Errorf("a bool variable must be true or false")
+		}
+
+		variable.Value = &gen.Variable_Bool{Bool: b}
+	case "number":
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return fmt.Errorf("a number variable must be an integer")
+		}
+
+		variable.Value = &gen.Variable_Num{Num: n}
+	case "hcl":
+		if _, diags := hclsyntax.ParseExpression([]byte(value), "value", hcl.InitialPos); diags.HasErrors() {
+			return fmt.Errorf("an hcl variable must be an HCL expression: %s", diags[0].Summary)
+		}
+
+		variable.Value = &gen.Variable_Hcl{Hcl: value}
+	default:
+		variable.Value = &gen.Variable_Str{Str: value}
+	}
+
+	return nil
+}
+
+// flattenProjectVariable sets data from variable. The value of sensitive
+// variables is kept from data, as the server may not return it as set.
+func flattenProjectVariable(data *projectVariableResourceModel, variable *gen.Variable) {
+	data.Id = types.StringValue(data.ProjectName.ValueString() + "/" + variable.Name)
+	data.Sensitive = types.BoolValue(variable.Sensitive)
+
+	var typ, value string
+
+	switch v := variable.Value.(type) {
+	case *gen.Variable_Bool:
+		typ, value = "bool", strconv.FormatBool(v.Bool)
+	case *gen.Variable_Num:
+		typ, value = "number", strconv.FormatInt(v.Num, 10)
+	case *gen.Variable_Hcl:
+		typ, value = "hcl", v.Hcl
+	case *gen.Variable_Str:
+		typ, value = "string", v.Str
+	}
+
+	data.Type = types.StringValue(typ)
+
+	if !variable.Sensitive || data.Value.IsNull() {
+		data.Value = types.StringValue(value)
+	}
+}
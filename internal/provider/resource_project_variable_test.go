@@ -0,0 +1,187 @@
+package waypoint
+
+import (
+	"fmt"
+	"regexp"
+	"testing"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+)
+
+func TestAccWaypointProjectVariable(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckProjectDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccProjectVariable(rName, "8080"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_project_variable.port", "id", rName+"/port"),
+					resource.TestCheckResourceAttr(
+						"waypoint_project_variable.port", "type", "number"),
+					resource.TestCheckResourceAttr(
+						"waypoint_project_variable.port", "value", "8080"),
+					resource.TestCheckResourceAttr(
+						"waypoint_project_variable.token", "sensitive", "true"),
+					resource.TestCheckResourceAttr(
+						"waypoint_project.test", "project_variables.%", "1"),
+				),
+			},
+			{
+				Config: testAccProjectVariable(rName, "9090"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_project_variable.port", "value", "9090"),
+				),
+			},
+			{
+				ResourceName:            "waypoint_project_variable.port",
+				ImportState:             true,
+				ImportStateId:           rName + "/port",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"value"},
+			},
+			{
+				Config:      testAccProjectVariableConflict(rName),
+				ExpectError: regexp.MustCompile("Variable already exists"),
+			},
+			{
+				Config:      testAccProjectVariableConflictInProject(rName),
+				ExpectError: regexp.MustCompile("Variable already exists"),
+			},
+		},
+	})
+}
+
+func TestExpandVariableValue(t *testing.T) {
+	cases := []struct {
+		typ, value string
+		want       string
+		wantError  bool
+	}{
+		{"string", "hello", "hello", false},
+		{"bool", "true", "true", false},
+		{"bool", "yes", "", true},
+		{"number", "42", "42", false},
+		{"number", "4.2", "", true},
+		{"hcl", `["a", "b"]`, `["a", "b"]`, false},
+		{"hcl", `{ a = `, "", true},
+	}
+
+	for _, tc := range cases {
+		variable := &gen.Variable{Name: "v"}
+
+		err := expandVariableValue(variable, tc.typ, tc.value)
+		if (err != nil) != tc.wantError {
+			t.Errorf("%s %q: got error %v, want error %t", tc.typ, tc.value, err, tc.wantError)
+			continue
+		}
+
+		if err != nil {
+			continue
+		}
+
+		data := projectVariableResourceModel{ProjectName: types.StringValue("p"), Value: types.StringNull()}
+		flattenProjectVariable(&data, variable)
+
+		if data.Type.ValueString() != tc.typ || data.Value.ValueString() != tc.want {
+			t.Errorf("%s %q: got %s %q", tc.typ, tc.value, data.Type.ValueString(), data.Value.ValueString())
+		}
+	}
+}
+
+func TestFlattenProjectVariableSensitive(t *testing.T) {
+	data := projectVariableResourceModel{ProjectName: types.StringValue("p"), Value: types.StringValue("secret")}
+
+	flattenProjectVariable(&data, &gen.Variable{
+		Name:      "token",
+		Value:     &gen.Variable_Str{Str: "redacted"},
+		Sensitive: true,
+	})
+
+	if data.Value.ValueString() != "secret" {
+		t.Errorf("got value %q, want the prior value", data.Value.ValueString())
+	}
+
+	if data.Id.ValueString() != "p/token" {
+		t.Errorf("got ID %q, want p/token", data.Id.ValueString())
+	}
+}
+
+func testAccProjectVariable(name, port string) string {
+	return fmt.Sprintf(`
+resource "waypoint_project" "test" {
+  project_name = "%s"
+
+  data_source_git {
+    git_url = "https://github.com/hashicorp/waypoint-examples"
+    git_ref = "HEAD"
+  }
+
+  project_variables = {
+    team = "platform"
+  }
+}
+
+resource "waypoint_project_variable" "port" {
+  project_name = waypoint_project.test.project_name
+  name         = "port"
+  type         = "number"
+  value        = "%s"
+}
+
+resource "waypoint_project_variable" "token" {
+  project_name = waypoint_project.test.project_name
+  name         = "token"
+  value        = "s3cr3t"
+  sensitive    = true
+}`, name, port)
+}
+
+func testAccProjectVariableConflict(name string) string {
+	return testAccProjectVariable(name, "9090") + `
+
+resource "waypoint_project_variable" "team" {
+  project_name = waypoint_project.test.project_name
+  name         = "team"
+  value        = "other"
+}`
+}
+
+func testAccProjectVariableConflictInProject(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_project" "test" {
+  project_name = "%s"
+
+  data_source_git {
+    git_url = "https://github.com/hashicorp/waypoint-examples"
+    git_ref = "HEAD"
+  }
+
+  project_variables = {
+    team = "platform"
+    port = "8080"
+  }
+}
+
+resource "waypoint_project_variable" "port" {
+  project_name = waypoint_project.test.project_name
+  name         = "port"
+  type         = "number"
+  value        = "9090"
+}
+
+resource "waypoint_project_variable" "token" {
+  project_name = waypoint_project.test.project_name
+  name         = "token"
+  value        = "s3cr3t"
+  sensitive    = true
+}`, name)
+}
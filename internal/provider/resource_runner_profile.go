@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"github.com/hashicorp-dev-advocates/waypoint-client/pkg/client"
 	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/hcl/v2"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/diag"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/validation"
+	"google.golang.org/protobuf/types/known/emptypb"
 )
 
 func resourceRunnerProfile() *schema.Resource {
@@ -19,61 +23,194 @@ func resourceRunnerProfile() *schema.Resource {
 		DeleteContext: resourceRunnerProfileDelete,
 		UpdateContext: resourceRunnerProfileUpdate,
 
+		CustomizeDiff: resourceRunnerProfileCustomizeDiff,
+
+		ValidateRawResourceConfigFuncs: []schema.ValidateRawResourceConfigFunc{
+			validateRunnerProfilePluginConfig,
+			validateRunnerProfilePluginConfigBlocks,
+		},
+
+		SchemaVersion: 1,
+		StateUpgraders: []schema.StateUpgrader{
+			{
+				Version: 0,
+				Type:    resourceRunnerProfileV0().CoreConfigSchema().ImpliedType(),
+				Upgrade: resourceRunnerProfileStateUpgradeV0,
+			},
+		},
+
+		Schema: resourceRunnerProfileSchema(),
+	}
+}
+
+func resourceRunnerProfileSchema() map[string]*schema.Schema {
+	return map[string]*schema.Schema{
+		"profile_name": {
+			Type:        schema.TypeString,
+			Required:    true,
+			Description: "The name of the runner profile",
+		},
+		"id": {
+			Type:        schema.TypeString,
+			Computed:    true,
+			Description: "Computed ID of runner profile.",
+		},
+		"oci_url": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "oci_url is the OCI image that will be used to boot the on demand runner.",
+		},
+		"plugin_type": {
+			Type:        schema.TypeString,
+			Optional:    true,
+			Description: "Plugin type for runner i.e docker / kubernetes / aws-ecs.",
+		},
+		"plugin_config": {
+			Type:             schema.TypeString, // Under the hood the type is []byte
+			Optional:         true,
+			DiffSuppressFunc: suppressEquivalentConfig("plugin_config_format"),
+			Description:      "plugin config is the configuration for the plugin that is created. It is usually HCL and is decoded like the other plugins, and is plugin specific. It is validated at plan time against the keys accepted by the docker, kubernetes, aws-ecs and nomad plugins. Differences in formatting, comments and key order are ignored.",
+		},
+		"plugin_config_format": {
+			Type:         schema.TypeInt,
+			Optional:     true,
+			ValidateFunc: validation.IntInSlice([]int{int(gen.Hcl_HCL), int(gen.Hcl_JSON)}),
+			Description:  "config format specifies the format of plugin_config, `0` for HCL or `1` for JSON.",
+		},
+		"docker_config":     runnerPluginConfigBlockSchema("docker_config"),
+		"ecs_config":        runnerPluginConfigBlockSchema("ecs_config"),
+		"kubernetes_config": runnerPluginConfigBlockSchema("kubernetes_config"),
+		"nomad_config":      runnerPluginConfigBlockSchema("nomad_config"),
+		"default": {
+			Type:        schema.TypeBool,
+			Optional:    true,
+			Description: "Indicates if this runner profile is the default for any new projects. Only one runner profile can be the default, so setting this moves the default from any other profile.",
+		},
+		"default_conflict": {
+			Type:         schema.TypeString,
+			Optional:     true,
+			Default:      "warn",
+			ValidateFunc: validation.StringInSlice([]string{"warn", "error"}, false),
+			Description:  "What to do when `default` is set and another runner profile is already the default, `warn` to take over the default, listing the other profiles in `default_conflict_with` in the plan, or `error` to fail the plan.",
+		},
+		"default_conflict_with": {
+			Type:        schema.TypeList,
+			Computed:    true,
+			Description: "Names of the runner profiles that were the default when `default` was last set, and that this profile takes the default from.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"target_runner": {
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: "The runners that may launch on demand runners for this profile. Defaults to any runner, which removing the block reverts to. Replaces the deprecated `target_runner_id` and `target_runner_labels` arguments.",
+			Elem: &schema.Resource{
+				Schema: map[string]*schema.Schema{
+					"id": {
+						Type:         schema.TypeString,
+						Optional:     true,
+						Description:  "The ID of the target runner.",
+						ExactlyOneOf: targetRunnerKeys,
+					},
+					"labels": {
+						Type:         schema.TypeMap,
+						Optional:     true,
+						Description:  "A map of labels on target runners.",
+						ExactlyOneOf: targetRunnerKeys,
+						Elem: &schema.Schema{
+							Type: schema.TypeString,
+						},
+					},
+					"any": {
+						Type:             schema.TypeBool,
+						Optional:         true,
+						Description:      "Target any runner. Can only be set to `true`.",
+						ExactlyOneOf:     targetRunnerKeys,
+						ValidateDiagFunc: validateTargetRunnerAny,
+					},
+				},
+			},
+		},
+		"target_runner_id": {
+			Type:          schema.TypeString,
+			Optional:      true,
+			Description:   "The ID of the target runner for this profile.",
+			Deprecated:    "Use target_runner { id = ... } instead. This argument will be removed in the next release.",
+			ConflictsWith: []string{"target_runner", "target_runner_labels"},
+		},
+		"target_runner_labels": {
+			Type:          schema.TypeMap,
+			Optional:      true,
+			Description:   "A map of labels on target runners",
+			Deprecated:    "Use target_runner { labels = ... } instead. This argument will be removed in the next release.",
+			ConflictsWith: []string{"target_runner", "target_runner_id"},
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+		"environment_variables": {
+			Type:        schema.TypeMap,
+			Optional:    true,
+			Description: "Any env vars that should be exposed to the on demand runner.",
+			Elem: &schema.Schema{
+				Type: schema.TypeString,
+			},
+		},
+	}
+}
+
+var targetRunnerKeys = []string{"target_runner.0.id", "target_runner.0.labels", "target_runner.0.any"}
+
+// resourceRunnerProfileV0 is the schema of waypoint_runner_profile before
+// the target_runner block, frozen as it was released. Only its type is
+// used, to decode prior state.
+func resourceRunnerProfileV0() *schema.Resource {
+	return &schema.Resource{
 		Schema: map[string]*schema.Schema{
 			"profile_name": {
-				Type:        schema.TypeString,
-				Required:    true,
-				Description: "The name of the runner profile",
+				Type:     schema.TypeString,
+				Required: true,
 			},
 			"id": {
-				Type:        schema.TypeString,
-				Computed:    true,
-				Description: "Computed ID of runner profile.",
+				Type:     schema.TypeString,
+				Computed: true,
 			},
 			"oci_url": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "oci_url is the OCI image that will be used to boot the on demand runner.",
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"plugin_type": {
-				Type:        schema.TypeString,
-				Optional:    true,
-				Description: "Plugin type for runner i.e docker / kubernetes / aws-ecs.",
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"plugin_config": {
-				Type:        schema.TypeString, // Under the hood the type is []byte
-				Optional:    true,
-				Description: "plugin config is the configuration for the plugin that is created. It is usually HCL and is decoded like the other plugins, and is plugin specific.",
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"plugin_config_format": {
-				Type:        schema.TypeInt,
-				Optional:    true,
-				Description: "config format specifies the format of plugin_config.",
+				Type:     schema.TypeInt,
+				Optional: true,
 			},
 			"default": {
-				Type:        schema.TypeBool,
-				Optional:    true,
-				Description: "Indicates if this runner profile is the default for any new projects",
+				Type:     schema.TypeBool,
+				Optional: true,
 			},
 			"target_runner_id": {
-				Type:          schema.TypeString,
-				Optional:      true,
-				Description:   "The ID of the target runner for this profile.",
-				ConflictsWith: []string{"target_runner_labels"},
+				Type:     schema.TypeString,
+				Optional: true,
 			},
 			"target_runner_labels": {
-				Type:          schema.TypeMap,
-				Optional:      true,
-				Description:   "A map of labels on target runners",
-				ConflictsWith: []string{"target_runner_id"},
+				Type:     schema.TypeMap,
+				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
 			},
 			"environment_variables": {
-				Type:        schema.TypeMap,
-				Optional:    true,
-				Description: "Any env vars that should be exposed to the on demand runner.",
+				Type:     schema.TypeMap,
+				Optional: true,
 				Elem: &schema.Schema{
 					Type: schema.TypeString,
 				},
@@ -82,9 +219,36 @@ func resourceRunnerProfile() *schema.Resource {
 	}
 }
 
+// resourceRunnerProfileStateUpgradeV0 moves target_runner_id or
+// target_runner_labels into the target_runner block.
+func resourceRunnerProfileStateUpgradeV0(ctx context.Context, rawState map[string]interface{}, meta interface{}) (map[string]interface{}, error) {
+	if rawState == nil {
+		return rawState, nil
+	}
+
+	id, _ := rawState["target_runner_id"].(string)
+	labels, _ := rawState["target_runner_labels"].(map[string]interface{})
+
+	switch {
+	case id != "":
+		rawState["target_runner"] = []interface{}{map[string]interface{}{"id": id}}
+	case len(labels) > 0:
+		rawState["target_runner"] = []interface{}{map[string]interface{}{"labels": labels}}
+	}
+
+	delete(rawState, "target_runner_id")
+	delete(rawState, "target_runner_labels")
+
+	return rawState, nil
+}
+
 func resourceRunnerProfileCreate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	wp := m.(*WaypointClient).conn
 
+	if diags := m.(*WaypointClient).requireServerVersion("waypoint_runner_profile", minServerVersionRunnerProfile); diags.HasError() {
+		return diags
+	}
+
 	runnerConfig := client.DefaultRunnerConfig()
 	runnerConfig.Name = d.Get("profile_name").(string)
 
@@ -104,37 +268,16 @@ func resourceRunnerProfileCreate(ctx context.Context, d *schema.ResourceData, m
 		runnerConfig.ConfigFormat = pluginConfigFormat
 	}
 
-	if defaultProfile, ok := d.Get("default").(bool); ok {
-		runnerConfig.Default = defaultProfile
+	if name, values, ok := runnerProfilePluginConfigBlock(d); ok {
+		runnerConfig.PluginConfig = encodePluginConfig(runnerPluginConfigBlocks[name], values)
+		runnerConfig.ConfigFormat = int(gen.Hcl_HCL)
 	}
 
-	tRId := d.Get("target_runner_id").(string)
-	if len(tRId) > 0 {
-
-		if targetRunnerId, ok := d.Get("target_runner_id").(string); ok {
-			runnerConfig.TargetRunner = &gen.Ref_Runner{Target: &gen.Ref_Runner_Id{Id: &gen.Ref_RunnerId{Id: targetRunnerId}}}
-		}
+	if defaultProfile, ok := d.Get("default").(bool); ok {
+		runnerConfig.Default = defaultProfile
 	}
 
-	tRL := d.Get("target_runner_labels").(map[string]interface{})
-
-	if len(tRL) > 0 {
-
-		if targetRunnerLabels, ok := d.Get("target_runner_labels").(map[string]interface{}); ok {
-			labels := make(map[string]string)
-
-			for k, v := range targetRunnerLabels {
-				strKey := fmt.Sprintf("%v", k)
-				strValue := fmt.Sprintf("%v", v)
-				labels[strKey] = strValue
-			}
-
-			runnerConfig.TargetRunner.Target = &gen.Ref_Runner_Labels{
-				Labels: &gen.Ref_RunnerLabels{
-					Labels: labels,
-				}}
-		}
-	}
+	runnerConfig.TargetRunner = expandTargetRunner(d)
 
 	runnerVariables := make(map[string]string)
 	if environmentVariables, ok := d.Get("environment_variables").(map[string]interface{}); ok {
@@ -149,6 +292,8 @@ func resourceRunnerProfileCreate(ctx context.Context, d *schema.ResourceData, m
 
 	}
 
+	diags := runnerProfileDefaultWarnings(d)
+
 	runnerProfile, err := wp.CreateRunnerProfile(context.TODO(), runnerConfig)
 
 	if err != nil {
@@ -159,7 +304,7 @@ func resourceRunnerProfileCreate(ctx context.Context, d *schema.ResourceData, m
 
 	tflog.Trace(ctx, "created a resource")
 
-	return resourceRunnerProfileRead(ctx, d, m)
+	return append(diags, resourceRunnerProfileRead(ctx, d, m)...)
 }
 
 func resourceRunnerProfileRead(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -177,16 +322,42 @@ func resourceRunnerProfileRead(ctx context.Context, d *schema.ResourceData, m in
 	d.Set("profile_name", getRunnerProfile.Config.Name)
 	d.Set("oci_url", getRunnerProfile.Config.OciUrl)
 	d.Set("plugin_type", getRunnerProfile.Config.PluginType)
-	d.Set("plugin_config", getRunnerProfile.Config.PluginConfig)
+
+	if name, _, ok := runnerProfilePluginConfigBlock(d); ok {
+		values, diags := decodePluginConfig(runnerPluginConfigBlocks[name], string(getRunnerProfile.Config.PluginConfig), getRunnerProfile.Config.ConfigFormat)
+		if diags.HasErrors() {
+			// The configuration was changed outside of Terraform to
+			// something the typed block cannot hold, so surface it as
+			// plugin_config to show the drift in the plan.
+			tflog.Warn(ctx, "unable to read plugin configuration into "+name, map[string]interface{}{
+				"error": diags.Error(),
+			})
+			d.Set("plugin_config", string(getRunnerProfile.Config.PluginConfig))
+		} else {
+			d.Set(name, []interface{}{values})
+		}
+	} else {
+		d.Set("plugin_config", string(getRunnerProfile.Config.PluginConfig))
+	}
+
 	d.Set("plugin_config_format", getRunnerProfile.Config.ConfigFormat)
 	d.Set("default", getRunnerProfile.Config.Default)
 
-	switch getRunnerProfile.Config.TargetRunner.Target.(type) {
-	case *gen.Ref_Runner_Labels:
-		d.Set("target_runner_labels", getRunnerProfile.Config.TargetRunner.Target.(*gen.Ref_Runner_Labels).Labels.Labels)
-	case *gen.Ref_Runner_Id:
-		d.Set("target_runner_id", getRunnerProfile.Config.TargetRunner.Target.(*gen.Ref_Runner_Id).Id.Id)
-
+	// The target is read back into the deprecated arguments while they are
+	// used instead of the target_runner block.
+	if usesDeprecatedTargetRunner(d) {
+		d.Set("target_runner", nil)
+		d.Set("target_runner_id", getRunnerProfile.Config.TargetRunner.GetId().GetId())
+		d.Set("target_runner_labels", getRunnerProfile.Config.TargetRunner.GetLabels().GetLabels())
+	} else {
+		// Targeting any runner is the default, so it is only read into a
+		// block that is configured, and removing the block plans a change
+		// back to it.
+		targetRunner := flattenTargetRunner(getRunnerProfile.Config.TargetRunner)
+		if _, ok := getRunnerProfile.Config.TargetRunner.GetTarget().(*gen.Ref_Runner_Any); ok && len(d.Get("target_runner").([]interface{})) == 0 {
+			targetRunner = nil
+		}
+		d.Set("target_runner", targetRunner)
 	}
 
 	d.Set("environment_variables", getRunnerProfile.Config.EnvironmentVariables)
@@ -197,6 +368,10 @@ func resourceRunnerProfileRead(ctx context.Context, d *schema.ResourceData, m in
 func resourceRunnerProfileUpdate(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
 	wp := m.(*WaypointClient).conn
 
+	if diags := m.(*WaypointClient).requireServerVersion("waypoint_runner_profile", minServerVersionRunnerProfile); diags.HasError() {
+		return diags
+	}
+
 	runnerConfig := client.DefaultRunnerConfig()
 	runnerConfig.Name = d.Get("profile_name").(string)
 	runnerConfig.Id = d.Get("id").(string)
@@ -217,37 +392,16 @@ func resourceRunnerProfileUpdate(ctx context.Context, d *schema.ResourceData, m
 		runnerConfig.ConfigFormat = pluginConfigFormat
 	}
 
-	if defaultProfile, ok := d.Get("default").(bool); ok {
-		runnerConfig.Default = defaultProfile
+	if name, values, ok := runnerProfilePluginConfigBlock(d); ok {
+		runnerConfig.PluginConfig = encodePluginConfig(runnerPluginConfigBlocks[name], values)
+		runnerConfig.ConfigFormat = int(gen.Hcl_HCL)
 	}
 
-	tRId := d.Get("target_runner_id").(string)
-	if len(tRId) > 0 {
-
-		if targetRunnerId, ok := d.Get("target_runner_id").(string); ok {
-			runnerConfig.TargetRunner = &gen.Ref_Runner{Target: &gen.Ref_Runner_Id{Id: &gen.Ref_RunnerId{Id: targetRunnerId}}}
-		}
+	if defaultProfile, ok := d.Get("default").(bool); ok {
+		runnerConfig.Default = defaultProfile
 	}
 
-	tRL := d.Get("target_runner_labels").(map[string]interface{})
-
-	if len(tRL) > 0 {
-
-		if targetRunnerLabels, ok := d.Get("target_runner_labels").(map[string]interface{}); ok {
-			labels := make(map[string]string)
-
-			for k, v := range targetRunnerLabels {
-				strKey := fmt.Sprintf("%v", k)
-				strValue := fmt.Sprintf("%v", v)
-				labels[strKey] = strValue
-			}
-
-			runnerConfig.TargetRunner.Target = &gen.Ref_Runner_Labels{
-				Labels: &gen.Ref_RunnerLabels{
-					Labels: labels,
-				}}
-		}
-	}
+	runnerConfig.TargetRunner = expandTargetRunner(d)
 
 	if environmentVariables, ok := d.Get("environment_variables").(map[string]interface{}); ok {
 		runnerVariables := make(map[string]string)
@@ -262,6 +416,8 @@ func resourceRunnerProfileUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	}
 
+	diags := runnerProfileDefaultWarnings(d)
+
 	runnerProfile, err := wp.CreateRunnerProfile(context.TODO(), runnerConfig)
 
 	if err != nil {
@@ -272,7 +428,7 @@ func resourceRunnerProfileUpdate(ctx context.Context, d *schema.ResourceData, m
 
 	tflog.Trace(ctx, "created a resource")
 
-	return resourceRunnerProfileRead(ctx, d, m)
+	return append(diags, resourceRunnerProfileRead(ctx, d, m)...)
 }
 
 func resourceRunnerProfileDelete(ctx context.Context, d *schema.ResourceData, m interface{}) diag.Diagnostics {
@@ -296,3 +452,298 @@ func resourceRunnerProfileDelete(ctx context.Context, d *schema.ResourceData, m
 
 	return resourceRunnerProfileRead(ctx, d, m)
 }
+
+// resourceRunnerProfileCustomizeDiff lists the runner profiles that
+// currently are the default when default is being set. The server only
+// keeps one default, so two profiles claiming it would take it from each
+// other on every apply. The conflict fails the plan if default_conflict is
+// "error", and is shown in the plan as default_conflict_with otherwise.
+func resourceRunnerProfileCustomizeDiff(ctx context.Context, d *schema.ResourceDiff, m interface{}) error {
+	if !d.HasChange("default") {
+		return nil
+	}
+
+	if !d.Get("default").(bool) {
+		return d.SetNew("default_conflict_with", []string{})
+	}
+
+	wpClient, ok := m.(*WaypointClient)
+	if !ok {
+		return nil
+	}
+
+	others, err := otherDefaultRunnerProfiles(ctx, wpClient.conn, d.Id())
+	if err != nil {
+		return fmt.Errorf("unable to list runner profiles: %w", err)
+	}
+
+	if len(others) > 0 && d.Get("default_conflict").(string) == "error" {
+		return fmt.Errorf("runner profile %q is already the default, and only one runner profile can be the default. "+
+			"Unset default on that profile first, or set default_conflict to \"warn\" to take over the default", others[0].Name)
+	}
+
+	return d.SetNew("default_conflict_with", runnerProfileNames(others))
+}
+
+// runnerProfileDefaultWarnings warns that setting default takes the default
+// from the runner profiles that held it when planned, as listed in
+// default_conflict_with by resourceRunnerProfileCustomizeDiff. They are not
+// listed again, so that the result matches the plan.
+func runnerProfileDefaultWarnings(d *schema.ResourceData) diag.Diagnostics {
+	var diags diag.Diagnostics
+
+	if !d.HasChange("default") || !d.Get("default").(bool) {
+		return diags
+	}
+
+	for _, other := range d.Get("default_conflict_with").([]interface{}) {
+		diags = append(diags, diag.Diagnostic{
+			Severity: diag.Warning,
+			Summary:  "Default runner profile changed",
+			Detail: fmt.Sprintf("Runner profile %q was the default and no longer is. If it is managed by Terraform with default = true, "+
+				"the two profiles will take the default from each other on every apply.", other),
+			AttributePath: cty.GetAttrPath("default"),
+		})
+	}
+
+	return diags
+}
+
+// runnerProfileNames returns the names of configs.
+func runnerProfileNames(configs []*gen.OnDemandRunnerConfig) []string {
+	names := make([]string, len(configs))
+	for i, config := range configs {
+		names[i] = config.Name
+	}
+
+	return names
+}
+
+// otherDefaultRunnerProfiles returns the runner profiles, other than the one
+// with the given id, that are the default.
+func otherDefaultRunnerProfiles(ctx context.Context, wp client.Waypoint, id string) ([]*gen.OnDemandRunnerConfig, error) {
+	resp, err := wp.GRPCClient().ListOnDemandRunnerConfigs(ctx, &emptypb.Empty{})
+	if err != nil {
+		return nil, err
+	}
+
+	var others []*gen.OnDemandRunnerConfig
+
+	for _, config := range resp.Configs {
+		if config.Default && config.Id != id {
+			others = append(others, config)
+		}
+	}
+
+	return others, nil
+}
+
+// usesDeprecatedTargetRunner reports whether the target of the runner
+// profile is set with target_runner_id or target_runner_labels rather than
+// the target_runner block.
+func usesDeprecatedTargetRunner(d *schema.ResourceData) bool {
+	if blocks, _ := d.Get("target_runner").([]interface{}); len(blocks) > 0 {
+		return false
+	}
+
+	id, _ := d.Get("target_runner_id").(string)
+	labels, _ := d.Get("target_runner_labels").(map[string]interface{})
+
+	return id != "" || len(labels) > 0
+}
+
+// expandTargetRunner returns the runner reference for the target_runner
+// block, or the deprecated target_runner_id and target_runner_labels,
+// targeting any runner when none is set.
+func expandTargetRunner(d *schema.ResourceData) *gen.Ref_Runner {
+	blocks := d.Get("target_runner").([]interface{})
+	if len(blocks) == 0 || blocks[0] == nil {
+		blocks = []interface{}{map[string]interface{}{
+			"id":     d.Get("target_runner_id"),
+			"labels": d.Get("target_runner_labels"),
+		}}
+	}
+
+	targetRunner := blocks[0].(map[string]interface{})
+
+	if id, ok := targetRunner["id"].(string); ok && id != "" {
+		return &gen.Ref_Runner{Target: &gen.Ref_Runner_Id{Id: &gen.Ref_RunnerId{Id: id}}}
+	}
+
+	if targetRunnerLabels, ok := targetRunner["labels"].(map[string]interface{}); ok && len(targetRunnerLabels) > 0 {
+		labels := make(map[string]string)
+
+		for k, v := range targetRunnerLabels {
+			labels[k] = fmt.Sprintf("%v", v)
+		}
+
+		return &gen.Ref_Runner{Target: &gen.Ref_Runner_Labels{Labels: &gen.Ref_RunnerLabels{Labels: labels}}}
+	}
+
+	return &gen.Ref_Runner{Target: &gen.Ref_Runner_Any{Any: &gen.Ref_RunnerAny{}}}
+}
+
+// flattenTargetRunner returns the target_runner block for a runner
+// reference.
+func flattenTargetRunner(ref *gen.Ref_Runner) []interface{} {
+	switch target := ref.GetTarget().(type) {
+	case *gen.Ref_Runner_Any:
+		return []interface{}{map[string]interface{}{"any": true}}
+	case *gen.Ref_Runner_Id:
+		return []interface{}{map[string]interface{}{"id": target.Id.GetId()}}
+	case *gen.Ref_Runner_Labels:
+		return []interface{}{map[string]interface{}{"labels": target.Labels.GetLabels()}}
+	}
+
+	return nil
+}
+
+func validateTargetRunnerAny(v interface{}, path cty.Path) diag.Diagnostics {
+	if targetAny, ok := v.(bool); ok && !targetAny {
+		return diag.Diagnostics{{
+			Severity:      diag.Error,
+			Summary:       "Invalid target_runner",
+			Detail:        "any can only be set to true. Set id or labels to target specific runners.",
+			AttributePath: path,
+		}}
+	}
+
+	return nil
+}
+
+// runnerPluginConfigBlockSchema returns the schema of a typed plugin
+// configuration block, which conflicts with plugin_config and the blocks for
+// other plugin types.
+func runnerPluginConfigBlockSchema(name string) *schema.Schema {
+	pluginType := runnerPluginConfigBlocks[name]
+
+	conflictsWith := []string{"plugin_config", "plugin_config_format"}
+	for _, other := range sortedKeys(runnerPluginConfigBlocks) {
+		if other != name {
+			conflictsWith = append(conflictsWith, other)
+		}
+	}
+
+	return &schema.Schema{
+		Type:          schema.TypeList,
+		Optional:      true,
+		MaxItems:      1,
+		ConflictsWith: conflictsWith,
+		Description: fmt.Sprintf("%s, serialized into the plugin config. Requires `plugin_type` to be `%s`.",
+			runnerPluginConfigSchemas[pluginType].Description, pluginType),
+		Elem: runnerPluginConfigSchemas[pluginType].resourceSchema(),
+	}
+}
+
+// runnerProfilePluginConfigBlock returns the name and values of the typed
+// plugin configuration block set on the runner profile, if any.
+func runnerProfilePluginConfigBlock(d *schema.ResourceData) (string, map[string]interface{}, bool) {
+	for _, name := range sortedKeys(runnerPluginConfigBlocks) {
+		// The data sources share resourceRunnerProfileRead but have no
+		// typed blocks.
+		blocks, _ := d.Get(name).([]interface{})
+		if len(blocks) == 0 {
+			continue
+		}
+
+		// An empty block is read as a nil element.
+		values, _ := blocks[0].(map[string]interface{})
+
+		return name, values, true
+	}
+
+	return "", nil, false
+}
+
+// validateRunnerProfilePluginConfigBlocks checks a typed plugin configuration
+// block is only used with the plugin_type it configures.
+func validateRunnerProfilePluginConfigBlocks(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+
+	pluginType := req.RawConfig.GetAttr("plugin_type")
+	if !pluginType.IsKnown() {
+		return
+	}
+
+	for _, name := range sortedKeys(runnerPluginConfigBlocks) {
+		block := req.RawConfig.GetAttr(name)
+		if !block.IsKnown() || block.IsNull() || block.LengthInt() == 0 {
+			continue
+		}
+
+		want := runnerPluginConfigBlocks[name]
+		if !pluginType.IsNull() && pluginType.AsString() == want {
+			continue
+		}
+
+		resp.Diagnostics = append(resp.Diagnostics, diag.Diagnostic{
+			Severity:      diag.Error,
+			Summary:       "Invalid plugin_type",
+			Detail:        fmt.Sprintf("plugin_type must be %q when %s is set.", want, name),
+			AttributePath: cty.GetAttrPath("plugin_type"),
+		})
+	}
+}
+
+// validateRunnerProfilePluginConfig validates plugin_config against
+// plugin_type and plugin_config_format, so that malformed configuration is
+// reported at plan time rather than when an on-demand runner is launched.
+func validateRunnerProfilePluginConfig(ctx context.Context, req schema.ValidateResourceConfigFuncRequest, resp *schema.ValidateResourceConfigFuncResponse) {
+	if !req.RawConfig.IsKnown() || req.RawConfig.IsNull() {
+		return
+	}
+
+	pluginConfig := req.RawConfig.GetAttr("plugin_config")
+	pluginType := req.RawConfig.GetAttr("plugin_type")
+	pluginConfigFormat := req.RawConfig.GetAttr("plugin_config_format")
+
+	// Values computed from other resources are validated once known.
+	if !pluginConfig.IsKnown() || !pluginType.IsKnown() || !pluginConfigFormat.IsKnown() {
+		return
+	}
+
+	if pluginConfig.IsNull() {
+		return
+	}
+
+	if pluginType.IsNull() {
+		pluginType = cty.StringVal("")
+	}
+
+	format := gen.Hcl_HCL
+	if !pluginConfigFormat.IsNull() {
+		value, _ := pluginConfigFormat.AsBigFloat().Int64()
+		if _, ok := gen.Hcl_Format_name[int32(value)]; !ok {
+			// Reported by the validation of plugin_config_format.
+			return
+		}
+		format = gen.Hcl_Format(value)
+	}
+
+	for _, d := range validatePluginConfig(pluginType.AsString(), pluginConfig.AsString(), format) {
+		resp.Diagnostics = append(resp.Diagnostics, pluginConfigDiagnostic(d, cty.GetAttrPath("plugin_config")))
+	}
+}
+
+// pluginConfigDiagnostic converts a diagnostic from parsing plugin_config
+// into one attached to the attribute at path.
+func pluginConfigDiagnostic(d *hcl.Diagnostic, path cty.Path) diag.Diagnostic {
+	severity := diag.Error
+	if d.Severity == hcl.DiagWarning {
+		severity = diag.Warning
+	}
+
+	detail := d.Detail
+	if d.Subject != nil {
+		detail = fmt.Sprintf("%s\n\nOn line %d, column %d of the plugin configuration.", d.Detail, d.Subject.Start.Line, d.Subject.Start.Column)
+	}
+
+	return diag.Diagnostic{
+		Severity:      severity,
+		Summary:       fmt.Sprintf("Invalid plugin_config: %s", d.Summary),
+		Detail:        detail,
+		AttributePath: path,
+	}
+}
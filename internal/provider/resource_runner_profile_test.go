@@ -1,10 +1,17 @@
 package waypoint
 
 import (
+	"context"
 	"fmt"
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
 	sdkacctest "github.com/hashicorp/terraform-plugin-sdk/v2/helper/acctest"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/resource"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
 	"github.com/hashicorp/terraform-plugin-sdk/v2/terraform"
+	"google.golang.org/protobuf/proto"
+	"reflect"
+	"regexp"
+	"strings"
 	"testing"
 )
 
@@ -12,9 +19,9 @@ func TestAccWaypointRunnerProfileTargetId(t *testing.T) {
 	rName := sdkacctest.RandomWithPrefix(providerName)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		CheckDestroy:      testAccCheckRunnerProfileDestroy,
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckRunnerProfileDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRunnerProfileTargetId(rName),
@@ -28,13 +35,22 @@ func TestAccWaypointRunnerProfileTargetId(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"waypoint_runner_profile.target_id", "default", "true"),
 					resource.TestCheckResourceAttr(
-						"waypoint_runner_profile.target_id", "target_runner_id", "01G5GNJEYC7RVJNXFGMHD0HCDT"),
+						"waypoint_runner_profile.target_id", "target_runner.0.id", "01G5GNJEYC7RVJNXFGMHD0HCDT"),
 					resource.TestCheckResourceAttr(
 						"waypoint_runner_profile.target_id", "environment_variables.VAULT_ADDR", "https://localhost:8200"),
 					resource.TestCheckResourceAttr(
 						"waypoint_runner_profile.target_id", "environment_variables.VAULT_CLIENT_TIMEOUT", "30s"),
 				),
 			},
+			{
+				// Removing the block targets any runner again.
+				Config: testAccRunnerProfileNoTarget(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.target_id", "target_runner.#", "0"),
+					testAccCheckRunnerProfileTargetsAny("waypoint_runner_profile.target_id"),
+				),
+			},
 		},
 	})
 }
@@ -43,9 +59,9 @@ func TestAccWaypointRunnerProfileTargetLabels(t *testing.T) {
 	rName := sdkacctest.RandomWithPrefix(providerName)
 
 	resource.UnitTest(t, resource.TestCase{
-		PreCheck:          func() { testAccPreCheck(t) },
-		CheckDestroy:      testAccCheckRunnerProfileDestroy,
-		ProviderFactories: providerFactories,
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckRunnerProfileDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
 		Steps: []resource.TestStep{
 			{
 				Config: testAccRunnerProfileTargetLabels(rName),
@@ -59,7 +75,7 @@ func TestAccWaypointRunnerProfileTargetLabels(t *testing.T) {
 					resource.TestCheckResourceAttr(
 						"waypoint_runner_profile.target_id", "default", "true"),
 					resource.TestCheckResourceAttr(
-						"waypoint_runner_profile.target_id", "target_runner_labels.app", "payments"),
+						"waypoint_runner_profile.target_id", "target_runner.0.labels.app", "payments"),
 					resource.TestCheckResourceAttr(
 						"waypoint_runner_profile.target_id", "environment_variables.VAULT_ADDR", "https://localhost:8200"),
 					resource.TestCheckResourceAttr(
@@ -70,6 +86,248 @@ func TestAccWaypointRunnerProfileTargetLabels(t *testing.T) {
 	})
 }
 
+func TestAccWaypointRunnerProfileTargetAny(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckRunnerProfileDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunnerProfileTargetAny(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.target_any", "target_runner.0.any", "true"),
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.target_any", "target_runner.0.id", ""),
+				),
+			},
+			{
+				Config:      testAccRunnerProfileTargetAnyAndId(rName),
+				ExpectError: regexp.MustCompile(`only one of`),
+			},
+		},
+	})
+}
+
+func TestAccWaypointRunnerProfileUpgradeTargetRunner(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:     func() { testAccPreCheck(t) },
+		CheckDestroy: testAccCheckRunnerProfileDestroy,
+		Steps: []resource.TestStep{
+			{
+				ExternalProviders: map[string]resource.ExternalProvider{
+					"waypoint": {
+						Source:            "hashicorp-dev-advocates/waypoint",
+						VersionConstraint: "0.2.1",
+					},
+				},
+				Config: testAccRunnerProfileTargetIdV0(rName),
+			},
+			{
+				ProtoV5ProviderFactories: protoV5ProviderFactories,
+				Config:                   testAccRunnerProfileTargetId(rName),
+				PlanOnly:                 true,
+			},
+		},
+	})
+}
+
+func TestAccWaypointRunnerProfileDeprecatedTargetId(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckRunnerProfileDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunnerProfileTargetIdV0(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.target_id", "target_runner_id", "01G5GNJEYC7RVJNXFGMHD0HCDT"),
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.target_id", "target_runner.#", "0"),
+				),
+			},
+			{
+				Config:   testAccRunnerProfileTargetIdV0(rName),
+				PlanOnly: true,
+			},
+			{
+				Config:      testAccRunnerProfileTargetIdAndDeprecatedId(rName),
+				ExpectError: regexp.MustCompile(`conflicts with`),
+			},
+		},
+	})
+}
+
+func TestExpandTargetRunner(t *testing.T) {
+	cases := []struct {
+		name   string
+		config map[string]interface{}
+		want   *gen.Ref_Runner
+	}{
+		{
+			name:   "block",
+			config: map[string]interface{}{"target_runner": []interface{}{map[string]interface{}{"id": "a"}}},
+			want:   &gen.Ref_Runner{Target: &gen.Ref_Runner_Id{Id: &gen.Ref_RunnerId{Id: "a"}}},
+		},
+		{
+			name:   "deprecated id",
+			config: map[string]interface{}{"target_runner_id": "a"},
+			want:   &gen.Ref_Runner{Target: &gen.Ref_Runner_Id{Id: &gen.Ref_RunnerId{Id: "a"}}},
+		},
+		{
+			name:   "deprecated labels",
+			config: map[string]interface{}{"target_runner_labels": map[string]interface{}{"app": "payments"}},
+			want:   &gen.Ref_Runner{Target: &gen.Ref_Runner_Labels{Labels: &gen.Ref_RunnerLabels{Labels: map[string]string{"app": "payments"}}}},
+		},
+		{
+			name:   "none",
+			config: map[string]interface{}{},
+			want:   &gen.Ref_Runner{Target: &gen.Ref_Runner_Any{Any: &gen.Ref_RunnerAny{}}},
+		},
+	}
+
+	for _, tc := range cases {
+		tc.config["profile_name"] = "example"
+		d := schema.TestResourceDataRaw(t, resourceRunnerProfileSchema(), tc.config)
+
+		if got := expandTargetRunner(d); !proto.Equal(got, tc.want) {
+			t.Errorf("%s: got %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestRunnerProfileDefaultWarnings(t *testing.T) {
+	d := schema.TestResourceDataRaw(t, resourceRunnerProfileSchema(), map[string]interface{}{
+		"profile_name": "example",
+		"default":      true,
+	})
+	d.Set("default_conflict_with", []string{"planned"})
+
+	diags := runnerProfileDefaultWarnings(d)
+	if len(diags) != 1 || !strings.Contains(diags[0].Detail, `"planned"`) {
+		t.Fatalf("got %v, want a warning about the planned profile", diags)
+	}
+
+	if got := d.Get("default_conflict_with").([]interface{}); len(got) != 1 || got[0] != "planned" {
+		t.Errorf("got default_conflict_with %v, want the planned value", got)
+	}
+}
+
+func TestResourceRunnerProfileStateUpgradeV0(t *testing.T) {
+	cases := []struct {
+		name     string
+		rawState map[string]interface{}
+		want     map[string]interface{}
+	}{
+		{
+			name:     "id",
+			rawState: map[string]interface{}{"profile_name": "example", "target_runner_id": "01G5GNJEYC7RVJNXFGMHD0HCDT", "target_runner_labels": map[string]interface{}{}},
+			want:     map[string]interface{}{"profile_name": "example", "target_runner": []interface{}{map[string]interface{}{"id": "01G5GNJEYC7RVJNXFGMHD0HCDT"}}},
+		},
+		{
+			name:     "labels",
+			rawState: map[string]interface{}{"profile_name": "example", "target_runner_id": "", "target_runner_labels": map[string]interface{}{"app": "payments"}},
+			want:     map[string]interface{}{"profile_name": "example", "target_runner": []interface{}{map[string]interface{}{"labels": map[string]interface{}{"app": "payments"}}}},
+		},
+		{
+			name:     "neither",
+			rawState: map[string]interface{}{"profile_name": "example"},
+			want:     map[string]interface{}{"profile_name": "example"},
+		},
+	}
+
+	for _, tc := range cases {
+		got, err := resourceRunnerProfileStateUpgradeV0(context.Background(), tc.rawState, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", tc.name, err)
+		}
+
+		if !reflect.DeepEqual(got, tc.want) {
+			t.Errorf("%s: got %#v, want %#v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestResourceRunnerProfileV0Schema(t *testing.T) {
+	want := []string{
+		"default", "environment_variables", "id", "oci_url", "plugin_config", "plugin_config_format",
+		"plugin_type", "profile_name", "target_runner_id", "target_runner_labels",
+	}
+
+	if got := sortedKeys(resourceRunnerProfileV0().Schema); !reflect.DeepEqual(got, want) {
+		t.Errorf("got V0 attributes %v, want %v", got, want)
+	}
+}
+
+func TestAccWaypointRunnerProfileKubernetesConfig(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckRunnerProfileDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunnerProfileKubernetesConfig(rName),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.kubernetes", "plugin_type", "kubernetes"),
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.kubernetes", "kubernetes_config.0.namespace", "waypoint"),
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.kubernetes", "kubernetes_config.0.cpu.0.request", "250m"),
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.kubernetes", "plugin_config_format", "0"),
+				),
+			},
+		},
+	})
+}
+
+func TestAccWaypointRunnerProfileDefaultConflict(t *testing.T) {
+	rName := sdkacctest.RandomWithPrefix(providerName)
+
+	resource.UnitTest(t, resource.TestCase{
+		PreCheck:                 func() { testAccPreCheck(t) },
+		CheckDestroy:             testAccCheckRunnerProfileDestroy,
+		ProtoV5ProviderFactories: protoV5ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: testAccRunnerProfileTargetId(rName),
+			},
+			{
+				Config:      testAccRunnerProfileTargetId(rName) + testAccRunnerProfileDefaultConflict(rName, "error"),
+				ExpectError: regexp.MustCompile(`is already the default`),
+			},
+			{
+				// The default is taken over, and the profile it is taken
+				// from is known when planning.
+				Config:             testAccRunnerProfileTargetId(rName) + testAccRunnerProfileDefaultConflict(rName, "warn"),
+				PlanOnly:           true,
+				ExpectNonEmptyPlan: true,
+			},
+			{
+				Config: testAccRunnerProfileTargetId(rName) + testAccRunnerProfileDefaultConflict(rName, "warn"),
+				Check: resource.ComposeTestCheckFunc(
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.conflict", "default_conflict_with.#", "1"),
+					resource.TestCheckResourceAttr(
+						"waypoint_runner_profile.conflict", "default_conflict_with.0", rName),
+				),
+				// target_id claims the default back on the next apply.
+				ExpectNonEmptyPlan: true,
+			},
+		},
+	})
+}
+
 func testAccCheckRunnerProfileDestroy(s *terraform.State) error {
 	for _, rs := range s.RootModule().Resources {
 		if rs.Type != "runner_profile" {
@@ -85,11 +343,53 @@ func testAccCheckRunnerProfileDestroy(s *terraform.State) error {
 func testAccRunnerProfileTargetId(name string) string {
 	return fmt.Sprintf(`
 resource "waypoint_runner_profile" "target_id" {
-  profile_name     = "%s"
-  oci_url          = "hashicorp/waypoint-odr:latest"
-  plugin_type      = "docker"
-  default          = true
-  target_runner_id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
+
+  environment_variables = {
+    VAULT_ADDR           = "https://localhost:8200"
+    VAULT_CLIENT_TIMEOUT = "30s"
+  }
+}`, name)
+}
+
+// testAccCheckRunnerProfileTargetsAny checks the runner profile of the
+// resource targets any runner on the server.
+func testAccCheckRunnerProfileTargetsAny(name string) resource.TestCheckFunc {
+	return func(s *terraform.State) error {
+		rs, ok := s.RootModule().Resources[name]
+		if !ok {
+			return fmt.Errorf("resource %s not found", name)
+		}
+
+		client := waypointProvider.Meta().(*WaypointClient)
+
+		profile, err := client.conn.GetRunnerProfile(context.Background(), rs.Primary.ID)
+		if err != nil {
+			return err
+		}
+
+		if _, ok := profile.Config.TargetRunner.GetTarget().(*gen.Ref_Runner_Any); !ok {
+			return fmt.Errorf("runner profile %s targets %v, want any runner", rs.Primary.ID, profile.Config.TargetRunner)
+		}
+
+		return nil
+	}
+}
+
+func testAccRunnerProfileNoTarget(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "target_id" {
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
 
   environment_variables = {
     VAULT_ADDR           = "https://localhost:8200"
@@ -101,14 +401,97 @@ resource "waypoint_runner_profile" "target_id" {
 func testAccRunnerProfileTargetLabels(name string) string {
 	return fmt.Sprintf(`
 resource "waypoint_runner_profile" "target_id" {
-  profile_name     = "%s"
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+  default      = true
+
+  target_runner {
+    labels = {
+      app = "payments"
+    }
+  }
+
+  environment_variables = {
+    VAULT_ADDR           = "https://localhost:8200"
+    VAULT_CLIENT_TIMEOUT = "30s"
+  }
+}`, name)
+}
+
+func testAccRunnerProfileKubernetesConfig(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "kubernetes" {
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "kubernetes"
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
+
+  kubernetes_config {
+    namespace = "waypoint"
+
+    cpu {
+      request = "250m"
+    }
+  }
+}`, name)
+}
+
+func testAccRunnerProfileDefaultConflict(name, mode string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "conflict" {
+  profile_name     = "%s-conflict"
   oci_url          = "hashicorp/waypoint-odr:latest"
   plugin_type      = "docker"
   default          = true
+  default_conflict = %q
+
+  depends_on = [
+    waypoint_runner_profile.target_id
+  ]
+}`, name, mode)
+}
+
+func testAccRunnerProfileTargetAny(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "target_any" {
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
+
+  target_runner {
+    any = true
+  }
+}`, name)
+}
+
+func testAccRunnerProfileTargetAnyAndId(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "target_any" {
+  profile_name = "%s"
+  oci_url      = "hashicorp/waypoint-odr:latest"
+  plugin_type  = "docker"
 
-  target_runner_labels = {
-    app = "payments"
+  target_runner {
+    any = true
+    id  = "01G5GNJEYC7RVJNXFGMHD0HCDT"
   }
+}`, name)
+}
+
+// testAccRunnerProfileTargetIdV0 is testAccRunnerProfileTargetId as written
+// for provider versions before the target_runner block.
+func testAccRunnerProfileTargetIdV0(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "target_id" {
+  profile_name     = "%s"
+  oci_url          = "hashicorp/waypoint-odr:latest"
+  plugin_type      = "docker"
+  default          = true
+  target_runner_id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
 
   environment_variables = {
     VAULT_ADDR           = "https://localhost:8200"
@@ -116,3 +499,17 @@ resource "waypoint_runner_profile" "target_id" {
   }
 }`, name)
 }
+
+func testAccRunnerProfileTargetIdAndDeprecatedId(name string) string {
+	return fmt.Sprintf(`
+resource "waypoint_runner_profile" "target_id" {
+  profile_name     = "%s"
+  oci_url          = "hashicorp/waypoint-odr:latest"
+  plugin_type      = "docker"
+  target_runner_id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+
+  target_runner {
+    id = "01G5GNJEYC7RVJNXFGMHD0HCDT"
+  }
+}`, name)
+}
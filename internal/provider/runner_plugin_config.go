@@ -0,0 +1,368 @@
+package waypoint
+
+import (
+	"fmt"
+	"sort"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/hcl/v2"
+	"github.com/hashicorp/hcl/v2/hclsyntax"
+	"github.com/hashicorp/hcl/v2/hclwrite"
+	hcljson "github.com/hashicorp/hcl/v2/json"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+	"github.com/zclconf/go-cty/cty"
+	"github.com/zclconf/go-cty/cty/convert"
+	"github.com/zclconf/go-cty/cty/gocty"
+)
+
+// runnerPluginConfigAttribute is a key of the plugin_config of a runner
+// profile. Lists and maps hold strings.
+type runnerPluginConfigAttribute struct {
+	Type        schema.ValueType
+	Description string
+}
+
+// runnerPluginConfigSchema describes the keys accepted in the plugin_config
+// of a runner profile, mirroring the task launcher configuration of the
+// Waypoint plugin.
+type runnerPluginConfigSchema struct {
+	Description string
+	Attributes  map[string]runnerPluginConfigAttribute
+	Blocks      map[string]*runnerPluginConfigSchema
+}
+
+var runnerPluginResourceAttributes = map[string]runnerPluginConfigAttribute{
+	"request": {Type: schema.TypeString, Description: "Amount of the resource requested for the runner, such as `250m`"},
+	"limit":   {Type: schema.TypeString, Description: "Maximum amount of the resource the runner may use"},
+}
+
+// runnerPluginConfigSchemas holds the plugin_config schema of the runner
+// plugins built into Waypoint, keyed by plugin_type. Other plugin types are
+// not checked.
+var runnerPluginConfigSchemas = map[string]*runnerPluginConfigSchema{
+	"docker": {
+		Description: "Configuration of the docker runner plugin",
+		Attributes: map[string]runnerPluginConfigAttribute{
+			"binds":              {Type: schema.TypeList, Description: "Volume binds for the runner container, in Docker `-v` format"},
+			"force_pull":         {Type: schema.TypeBool, Description: "Always pull the runner image, even if it is present locally"},
+			"labels":             {Type: schema.TypeMap, Description: "Labels to set on the runner container"},
+			"networks":           {Type: schema.TypeList, Description: "Networks to connect the runner container to"},
+			"resources":          {Type: schema.TypeMap, Description: "Resource constraints for the runner container, such as `cpu` and `memory`"},
+			"static_environment": {Type: schema.TypeMap, Description: "Environment variables to set in the runner container"},
+		},
+	},
+	"kubernetes": {
+		Description: "Configuration of the kubernetes runner plugin",
+		Attributes: map[string]runnerPluginConfigAttribute{
+			"context":                           {Type: schema.TypeString, Description: "Kubeconfig context to use"},
+			"image_pull_policy":                 {Type: schema.TypeString, Description: "Pull policy of the runner image"},
+			"image_secret":                      {Type: schema.TypeString, Description: "Name of the secret used to pull the runner image"},
+			"kubeconfig":                        {Type: schema.TypeString, Description: "Path to the kubeconfig file"},
+			"namespace":                         {Type: schema.TypeString, Description: "Namespace to launch runner pods in"},
+			"service_account":                   {Type: schema.TypeString, Description: "Service account of the runner pods"},
+			"watchtask_startup_timeout_seconds": {Type: schema.TypeInt, Description: "Seconds to wait for the runner pod to start"},
+		},
+		Blocks: map[string]*runnerPluginConfigSchema{
+			"cpu": {
+				Description: "CPU resources of the runner pods",
+				Attributes:  runnerPluginResourceAttributes,
+			},
+			"memory": {
+				Description: "Memory resources of the runner pods",
+				Attributes:  runnerPluginResourceAttributes,
+			},
+			"security_context": {
+				Description: "Security context of the runner pods",
+				Attributes: map[string]runnerPluginConfigAttribute{
+					"fs_group":        {Type: schema.TypeInt, Description: "Group ID that owns mounted volumes"},
+					"run_as_non_root": {Type: schema.TypeBool, Description: "Require the runner to run as a non-root user"},
+					"run_as_user":     {Type: schema.TypeInt, Description: "User ID to run the runner as"},
+				},
+			},
+		},
+	},
+	"aws-ecs": {
+		Description: "Configuration of the aws-ecs runner plugin",
+		Attributes: map[string]runnerPluginConfigAttribute{
+			"cluster":             {Type: schema.TypeString, Description: "ECS cluster to launch runner tasks in"},
+			"execution_role_name": {Type: schema.TypeString, Description: "IAM execution role of the runner tasks"},
+			"log_group":           {Type: schema.TypeString, Description: "CloudWatch log group of the runner tasks"},
+			"odr_cpu":             {Type: schema.TypeString, Description: "CPU units of the runner tasks"},
+			"odr_memory":          {Type: schema.TypeString, Description: "Memory, in MiB, of the runner tasks"},
+			"region":              {Type: schema.TypeString, Description: "AWS region to launch runner tasks in"},
+			"security_group_id":   {Type: schema.TypeString, Description: "Security group of the runner tasks"},
+			"subnets":             {Type: schema.TypeList, Description: "Subnets to launch runner tasks in"},
+			"task_role_name":      {Type: schema.TypeString, Description: "IAM task role of the runner tasks"},
+		},
+	},
+	"nomad": {
+		Description: "Configuration of the nomad runner plugin",
+		Attributes: map[string]runnerPluginConfigAttribute{
+			"datacenter":       {Type: schema.TypeString, Description: "Nomad datacenter to launch runner jobs in"},
+			"namespace":        {Type: schema.TypeString, Description: "Nomad namespace to launch runner jobs in"},
+			"nomad_host":       {Type: schema.TypeString, Description: "Address of the Nomad server"},
+			"region":           {Type: schema.TypeString, Description: "Nomad region to launch runner jobs in"},
+			"resources_cpu":    {Type: schema.TypeInt, Description: "CPU, in MHz, of the runner jobs"},
+			"resources_memory": {Type: schema.TypeInt, Description: "Memory, in MB, of the runner jobs"},
+		},
+	},
+}
+
+// runnerPluginConfigBlocks maps the typed plugin configuration blocks of
+// waypoint_runner_profile to the plugin_type they configure.
+var runnerPluginConfigBlocks = map[string]string{
+	"docker_config":     "docker",
+	"ecs_config":        "aws-ecs",
+	"kubernetes_config": "kubernetes",
+	"nomad_config":      "nomad",
+}
+
+// resourceSchema returns the schema of the typed block for the plugin
+// configuration, with every key optional.
+func (s *runnerPluginConfigSchema) resourceSchema() *schema.Resource {
+	resource := &schema.Resource{
+		Schema: map[string]*schema.Schema{},
+	}
+
+	for name, attribute := range s.Attributes {
+		attributeSchema := &schema.Schema{
+			Type:        attribute.Type,
+			Optional:    true,
+			Description: attribute.Description,
+		}
+
+		if attribute.Type == schema.TypeList || attribute.Type == schema.TypeMap {
+			attributeSchema.Elem = &schema.Schema{
+				Type: schema.TypeString,
+			}
+		}
+
+		resource.Schema[name] = attributeSchema
+	}
+
+	for name, block := range s.Blocks {
+		resource.Schema[name] = &schema.Schema{
+			Type:        schema.TypeList,
+			Optional:    true,
+			MaxItems:    1,
+			Description: block.Description,
+			Elem:        block.resourceSchema(),
+		}
+	}
+
+	return resource
+}
+
+func (s *runnerPluginConfigSchema) bodySchema() *hcl.BodySchema {
+	bodySchema := &hcl.BodySchema{}
+
+	for name := range s.Attributes {
+		bodySchema.Attributes = append(bodySchema.Attributes, hcl.AttributeSchema{Name: name})
+	}
+
+	for name := range s.Blocks {
+		bodySchema.Blocks = append(bodySchema.Blocks, hcl.BlockHeaderSchema{Type: name})
+	}
+
+	return bodySchema
+}
+
+// validate reports any key of body that the schema does not accept.
+func (s *runnerPluginConfigSchema) validate(body hcl.Body) hcl.Diagnostics {
+	content, diags := body.Content(s.bodySchema())
+
+	for _, block := range content.Blocks {
+		diags = append(diags, s.Blocks[block.Type].validate(block.Body)...)
+	}
+
+	return diags
+}
+
+// encode writes the values of a typed plugin configuration block into body.
+// Unset values are left out so the plugin applies its own defaults.
+func (s *runnerPluginConfigSchema) encode(body *hclwrite.Body, values map[string]interface{}) {
+	for _, name := range sortedKeys(s.Attributes) {
+		var value cty.Value
+
+		switch v := values[name].(type) {
+		case string:
+			if v == "" {
+				continue
+			}
+			value = cty.StringVal(v)
+		case int:
+			if v == 0 {
+				continue
+			}
+			value = cty.NumberIntVal(int64(v))
+		case bool:
+			if !v {
+				continue
+			}
+			value = cty.BoolVal(v)
+		case []interface{}:
+			if len(v) == 0 {
+				continue
+			}
+			elements := make([]cty.Value, len(v))
+			for i, element := range v {
+				elements[i] = cty.StringVal(element.(string))
+			}
+			value = cty.ListVal(elements)
+		case map[string]interface{}:
+			if len(v) == 0 {
+				continue
+			}
+			elements := make(map[string]cty.Value, len(v))
+			for key, element := range v {
+				elements[key] = cty.StringVal(element.(string))
+			}
+			value = cty.MapVal(elements)
+		default:
+			continue
+		}
+
+		body.SetAttributeValue(name, value)
+	}
+
+	for _, name := range sortedKeys(s.Blocks) {
+		blockValues, ok := values[name].([]interface{})
+		if !ok || len(blockValues) == 0 || blockValues[0] == nil {
+			continue
+		}
+
+		s.Blocks[name].encode(body.AppendNewBlock(name, nil).Body(), blockValues[0].(map[string]interface{}))
+	}
+}
+
+// decode reads body into the values of a typed plugin configuration block.
+func (s *runnerPluginConfigSchema) decode(body hcl.Body) (map[string]interface{}, hcl.Diagnostics) {
+	content, diags := body.Content(s.bodySchema())
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	values := map[string]interface{}{}
+
+	for name, attribute := range content.Attributes {
+		value, d := attribute.Expr.Value(nil)
+		diags = append(diags, d...)
+		if d.HasErrors() {
+			continue
+		}
+
+		v, err := decodePluginConfigValue(value, s.Attributes[name].Type)
+		values[name] = v
+
+		if err != nil {
+			diags = append(diags, &hcl.Diagnostic{
+				Severity: hcl.DiagError,
+				Summary:  "Incorrect attribute value type",
+				Detail:   err.Error(),
+				Subject:  attribute.Expr.Range().Ptr(),
+			})
+		}
+	}
+
+	for _, block := range content.Blocks {
+		blockValues, d := s.Blocks[block.Type].decode(block.Body)
+		diags = append(diags, d...)
+		values[block.Type] = []interface{}{blockValues}
+	}
+
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return values, nil
+}
+
+// decodePluginConfigValue converts an attribute value of the plugin
+// configuration to the Go type of the typed block attribute.
+func decodePluginConfigValue(value cty.Value, valueType schema.ValueType) (interface{}, error) {
+	// Literals such as [...] and {...} are tuples and objects, so values are
+	// converted to the type of the attribute before decoding.
+	switch valueType {
+	case schema.TypeString:
+		var v string
+		return v, decodeCtyValue(value, cty.String, &v)
+	case schema.TypeInt:
+		var v int
+		return v, decodeCtyValue(value, cty.Number, &v)
+	case schema.TypeBool:
+		var v bool
+		return v, decodeCtyValue(value, cty.Bool, &v)
+	case schema.TypeList:
+		var v []string
+		return v, decodeCtyValue(value, cty.List(cty.String), &v)
+	case schema.TypeMap:
+		var v map[string]string
+		return v, decodeCtyValue(value, cty.Map(cty.String), &v)
+	}
+
+	return nil, fmt.Errorf("unsupported attribute type %s", valueType)
+}
+
+func decodeCtyValue(value cty.Value, ty cty.Type, target interface{}) error {
+	value, err := convert.Convert(value, ty)
+	if err != nil {
+		return err
+	}
+
+	return gocty.FromCtyValue(value, target)
+}
+
+// parsePluginConfig parses the plugin_config of a runner profile in the
+// given format.
+func parsePluginConfig(config string, format gen.Hcl_Format) (*hcl.File, hcl.Diagnostics) {
+	if format == gen.Hcl_JSON {
+		return hcljson.Parse([]byte(config), "plugin_config")
+	}
+
+	return hclsyntax.ParseConfig([]byte(config), "plugin_config", hcl.InitialPos)
+}
+
+// validatePluginConfig parses config and, for the plugin types built into
+// Waypoint, checks it only uses keys the plugin accepts.
+func validatePluginConfig(pluginType string, config string, format gen.Hcl_Format) hcl.Diagnostics {
+	file, diags := parsePluginConfig(config, format)
+	if diags.HasErrors() {
+		return diags
+	}
+
+	if pluginSchema, ok := runnerPluginConfigSchemas[pluginType]; ok {
+		diags = append(diags, pluginSchema.validate(file.Body)...)
+	}
+
+	return diags
+}
+
+// encodePluginConfig renders the values of the typed configuration block
+// for pluginType as HCL.
+func encodePluginConfig(pluginType string, values map[string]interface{}) []byte {
+	file := hclwrite.NewEmptyFile()
+	runnerPluginConfigSchemas[pluginType].encode(file.Body(), values)
+
+	return file.Bytes()
+}
+
+// decodePluginConfig parses config into the values of the typed
+// configuration block for pluginType.
+func decodePluginConfig(pluginType string, config string, format gen.Hcl_Format) (map[string]interface{}, hcl.Diagnostics) {
+	file, diags := parsePluginConfig(config, format)
+	if diags.HasErrors() {
+		return nil, diags
+	}
+
+	return runnerPluginConfigSchemas[pluginType].decode(file.Body)
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,190 @@
+package waypoint
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	gen "github.com/hashicorp-dev-advocates/waypoint-client/pkg/waypoint"
+	"github.com/hashicorp/go-cty/cty"
+	"github.com/hashicorp/terraform-plugin-sdk/v2/helper/schema"
+)
+
+func TestValidateRunnerProfilePluginConfig(t *testing.T) {
+	cases := []struct {
+		name         string
+		pluginType   cty.Value
+		pluginConfig cty.Value
+		format       cty.Value
+		wantError    bool
+	}{
+		{
+			name:         "valid kubernetes hcl",
+			pluginType:   cty.StringVal("kubernetes"),
+			pluginConfig: cty.StringVal("namespace = \"waypoint\"\n\ncpu {\n  request = \"250m\"\n}\n"),
+			format:       cty.NullVal(cty.Number),
+		},
+		{
+			name:         "valid docker json",
+			pluginType:   cty.StringVal("docker"),
+			pluginConfig: cty.StringVal(`{"force_pull": true, "static_environment": {"A": "b"}}`),
+			format:       cty.NumberIntVal(1),
+		},
+		{
+			name:         "malformed hcl",
+			pluginType:   cty.StringVal("docker"),
+			pluginConfig: cty.StringVal("force_pull = \n"),
+			format:       cty.NumberIntVal(0),
+			wantError:    true,
+		},
+		{
+			name:         "hcl given as json",
+			pluginType:   cty.StringVal("docker"),
+			pluginConfig: cty.StringVal("force_pull = true\n"),
+			format:       cty.NumberIntVal(1),
+			wantError:    true,
+		},
+		{
+			name:         "unknown nomad key",
+			pluginType:   cty.StringVal("nomad"),
+			pluginConfig: cty.StringVal("datacenter = \"dc1\"\ndata_center = \"dc1\"\n"),
+			format:       cty.NullVal(cty.Number),
+			wantError:    true,
+		},
+		{
+			name:         "unknown key in kubernetes block",
+			pluginType:   cty.StringVal("kubernetes"),
+			pluginConfig: cty.StringVal("memory {\n  requests = \"512Mi\"\n}\n"),
+			format:       cty.NullVal(cty.Number),
+			wantError:    true,
+		},
+		{
+			name:         "aws-ecs key on docker",
+			pluginType:   cty.StringVal("docker"),
+			pluginConfig: cty.StringVal("cluster = \"waypoint\"\n"),
+			format:       cty.NullVal(cty.Number),
+			wantError:    true,
+		},
+		{
+			name:         "custom plugin keys are not checked",
+			pluginType:   cty.StringVal("my-plugin"),
+			pluginConfig: cty.StringVal("anything = true\n"),
+			format:       cty.NullVal(cty.Number),
+		},
+		{
+			name:         "unknown plugin_config",
+			pluginType:   cty.StringVal("docker"),
+			pluginConfig: cty.UnknownVal(cty.String),
+			format:       cty.NullVal(cty.Number),
+		},
+	}
+
+	for _, tc := range cases {
+		req := schema.ValidateResourceConfigFuncRequest{
+			RawConfig: cty.ObjectVal(map[string]cty.Value{
+				"plugin_type":          tc.pluginType,
+				"plugin_config":        tc.pluginConfig,
+				"plugin_config_format": tc.format,
+			}),
+		}
+		resp := &schema.ValidateResourceConfigFuncResponse{}
+
+		validateRunnerProfilePluginConfig(context.Background(), req, resp)
+
+		if resp.Diagnostics.HasError() != tc.wantError {
+			t.Errorf("%s: got error %t, want %t: %v", tc.name, resp.Diagnostics.HasError(), tc.wantError, resp.Diagnostics)
+		}
+
+		for _, d := range resp.Diagnostics {
+			if !d.AttributePath.Equals(cty.GetAttrPath("plugin_config")) {
+				t.Errorf("%s: diagnostic %q is not attached to plugin_config", tc.name, d.Summary)
+			}
+		}
+	}
+}
+
+func TestPluginConfigRoundTrip(t *testing.T) {
+	cases := []struct {
+		pluginType string
+		values     map[string]interface{}
+		want       string
+	}{
+		{
+			pluginType: "kubernetes",
+			values: map[string]interface{}{
+				"namespace":                         "waypoint",
+				"service_account":                   "",
+				"watchtask_startup_timeout_seconds": 60,
+				"cpu": []interface{}{
+					map[string]interface{}{"request": "250m", "limit": ""},
+				},
+				"memory": []interface{}{},
+			},
+			want: "namespace                         = \"waypoint\"\nwatchtask_startup_timeout_seconds = 60\ncpu {\n  request = \"250m\"\n}\n",
+		},
+		{
+			pluginType: "docker",
+			values: map[string]interface{}{
+				"force_pull":         true,
+				"networks":           []interface{}{"waypoint"},
+				"static_environment": map[string]interface{}{"LOG_LEVEL": "debug"},
+			},
+			want: "force_pull = true\nnetworks   = [\"waypoint\"]\nstatic_environment = {\n  LOG_LEVEL = \"debug\"\n}\n",
+		},
+	}
+
+	for _, tc := range cases {
+		config := string(encodePluginConfig(tc.pluginType, tc.values))
+		if config != tc.want {
+			t.Errorf("%s: encoded\n%s\nwant\n%s", tc.pluginType, config, tc.want)
+		}
+
+		if diags := validatePluginConfig(tc.pluginType, config, gen.Hcl_HCL); diags.HasErrors() {
+			t.Errorf("%s: encoded configuration is invalid: %s", tc.pluginType, diags.Error())
+		}
+
+		decoded, diags := decodePluginConfig(tc.pluginType, config, gen.Hcl_HCL)
+		if diags.HasErrors() {
+			t.Fatalf("%s: %s", tc.pluginType, diags.Error())
+		}
+
+		// Encoding the decoded values again must give the same configuration,
+		// so that Read does not show a diff.
+		if again := string(encodePluginConfig(tc.pluginType, toResourceDataValues(decoded))); again != config {
+			t.Errorf("%s: re-encoded\n%s\nwant\n%s", tc.pluginType, again, config)
+		}
+	}
+}
+
+// toResourceDataValues converts decoded values to the types returned by
+// ResourceData.Get.
+func toResourceDataValues(values map[string]interface{}) map[string]interface{} {
+	converted := map[string]interface{}{}
+
+	for key, value := range values {
+		switch v := value.(type) {
+		case []string:
+			list := make([]interface{}, len(v))
+			for i, element := range v {
+				list[i] = element
+			}
+			converted[key] = list
+		case map[string]string:
+			m := make(map[string]interface{}, len(v))
+			for k, element := range v {
+				m[k] = element
+			}
+			converted[key] = m
+		case []interface{}:
+			converted[key] = []interface{}{toResourceDataValues(v[0].(map[string]interface{}))}
+		default:
+			converted[key] = v
+		}
+	}
+
+	if reflect.DeepEqual(converted, map[string]interface{}{}) {
+		return nil
+	}
+
+	return converted
+}
@@ -1,9 +1,12 @@
 package main
 
 import (
+	"context"
 	"flag"
+	"log"
+
 	waypoint "github.com/hashicorp-dev-advocates/terraform-provider-waypoint/internal/provider"
-	"github.com/hashicorp/terraform-plugin-sdk/v2/plugin"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5server"
 )
 
 // Run "go generate" to format example terraform files and generate the docs for the registry/website
@@ -31,14 +34,22 @@ func main() {
 	flag.BoolVar(&debugMode, "debug", false, "set to true to run the provider with support for debuggers like delve")
 	flag.Parse()
 
-	opts := &plugin.ServeOpts{
-		Debug: debugMode,
+	// The SDKv2 and framework providers are muxed into a single server, so
+	// resources can be migrated to the framework one at a time.
+	providerServer, err := waypoint.ProviderServer(context.Background(), version)
+	if err != nil {
+		log.Fatal(err)
+	}
 
-		// TODO: update this string with the full name of your provider as used in your configs
-		ProviderAddr: "registry.terraform.io/hashicorp-dev-advocates/waypoint",
+	var serveOpts []tf5server.ServeOpt
 
-		ProviderFunc: waypoint.Provider,
+	if debugMode {
+		serveOpts = append(serveOpts, tf5server.WithManagedDebug())
 	}
 
-	plugin.Serve(opts)
+	// TODO: update this string with the full name of your provider as used in your configs
+	err = tf5server.Serve("registry.terraform.io/hashicorp-dev-advocates/waypoint", providerServer, serveOpts...)
+	if err != nil {
+		log.Fatal(err)
+	}
 }